@@ -0,0 +1,45 @@
+/**
+ * Check helpers shared by the test scripts
+ *
+ * Each check prints ✓ or ✗ with its label; finish() prints the
+ * "Overall: x/y checks passed" summary and whether every check passed.
+ */
+
+export interface Checks {
+    /** Passes when actual and expected serialize to the same JSON */
+    check(label: string, actual: unknown, expected: unknown): void;
+
+    /** Passes when the condition holds */
+    ok(label: string, condition: boolean): void;
+
+    /** Print the summary; true when every check passed */
+    finish(): boolean;
+}
+
+export function createChecks(): Checks {
+    const results: boolean[] = [];
+
+    return {
+        check(label, actual, expected) {
+            const passed = JSON.stringify(actual) === JSON.stringify(expected);
+            console.log(`  ${passed ? '✓' : '✗'} ${label}: ${JSON.stringify(actual)}${passed ? '' : ` (expected ${JSON.stringify(expected)})`}`);
+            results.push(passed);
+        },
+
+        ok(label, condition) {
+            console.log(`  ${condition ? '✓' : '✗'} ${label}`);
+            results.push(condition);
+        },
+
+        finish() {
+            const passed = results.filter(r => r).length;
+            console.log(`\nOverall: ${passed}/${results.length} checks passed`);
+            if (passed === results.length) {
+                console.log('✓ All tests passed!');
+                return true;
+            }
+            console.log('✗ Some checks failed');
+            return false;
+        }
+    };
+}
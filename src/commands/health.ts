@@ -140,11 +140,12 @@ function checkConfiguration(): HealthCheckResult[] {
 	const statusBarEnabled = config.get<boolean>('statusBar.enabled', true);
 	const hoverEnabled = config.get<boolean>('hover.enabled', true);
 	const gutterIconsEnabled = config.get<boolean>('gutterIcons.enabled', false);
+	const codeLensEnabled = config.get<boolean>('codeLens.enabled', false);
 
 	results.push({
 		category: 'Configuration',
 		status: 'pass',
-		message: `UI: StatusBar=${statusBarEnabled}, Hover=${hoverEnabled}, Gutter=${gutterIconsEnabled}`
+		message: `UI: StatusBar=${statusBarEnabled}, Hover=${hoverEnabled}, Gutter=${gutterIconsEnabled}, CodeLens=${codeLensEnabled}`
 	});
 
 	// Check debug settings
@@ -0,0 +1,79 @@
+/**
+ * Test for AnnotationVerifier
+ *
+ * Run with: npx ts-node src/detection/annotations/__tests__/test-annotations.ts
+ */
+
+import * as fs from 'fs';
+import * as path from 'path';
+import { AnnotationVerifier } from '../annotationVerifier';
+import { createChecks } from '../../../__tests__/checks';
+
+const tsCode = `
+// add returns the sum.
+// Complexity: High
+function add(a: number, b: number): number {
+    return a + b;
+}
+
+/**
+ * Formats a name.
+ * complexity: low
+ */
+function format(name: string): string {
+    return name.trim();
+}
+
+// Complexity: Medium
+
+function detached(): void {
+    return;
+}
+`;
+
+const pyCode = `# Complexity: Low
+def double(x):
+    return x * 2
+
+# Complexity: High
+@app.route("/health")
+@login_required
+def health():
+    return "ok"
+`;
+
+function testAnnotationVerifier(): boolean {
+    console.log('\n=== Testing AnnotationVerifier ===\n');
+    const { check, finish } = createChecks();
+
+    // Test 1: the annotated handler fixture
+    console.log('Test 1: Go handler fixture');
+    const fixturePath = path.join(__dirname, '../../complexity/__tests__/test-go-example.go');
+    const fixture = AnnotationVerifier.verify(fs.readFileSync(fixturePath, 'utf8'), fixturePath);
+    check('annotations found', fixture.map(c => `${c.functionName}=${c.annotated}`),
+        ['whoamiHandler=Low', 'quadraticHandler=Medium', 'knapsackHandler=High']);
+    check('annotation is the line above the function', fixture.map(c => c.functionLine - c.line), [1, 1, 1]);
+
+    // Test 2: comment styles and attachment
+    console.log('\nTest 2: Comment styles');
+    const ts = AnnotationVerifier.verify(tsCode, 'names.ts');
+    check('line and block comments, detached ignored', ts.map(c => c.functionName), ['add', 'format']);
+    check('stale annotation', ts.map(c => [c.annotated, c.computed, c.matches]), [['High', 'Low', false], ['low', 'Low', true]]);
+    const py = AnnotationVerifier.verify(pyCode, 'calc.py');
+    check('Python # comments', py.map(c => c.functionName), ['double', 'health']);
+    check('decorators between comment and def', py.map(c => [c.annotated, c.computed]), [['Low', 'Low'], ['High', 'Low']]);
+
+    // Test 3: thresholds and fixing
+    console.log('\nTest 3: Thresholds and fix');
+    const strict = AnnotationVerifier.verify(tsCode, 'names.ts', { low: 0, medium: 1, high: 2 });
+    check('file thresholds apply', strict.map(c => c.computed), ['Medium', 'Medium']);
+    const fixed = AnnotationVerifier.fix(tsCode, ts);
+    check('fix rewrites only the level', fixed.split('\n')[2], '// Complexity: Low');
+    check('matching annotations untouched', fixed.includes(' * complexity: low'), true);
+    check('fixed file verifies clean', AnnotationVerifier.verify(fixed, 'names.ts').every(c => c.matches), true);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testAnnotationVerifier() ? 0 : 1);
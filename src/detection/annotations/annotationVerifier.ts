@@ -0,0 +1,102 @@
+/**
+ * Annotation Verifier
+ * Checks "Complexity: Low|Medium|High|Very High" doc comments against computed levels
+ *
+ * An annotation belongs to a function when it is in the comment block
+ * directly above it (// and # line comments, or the lines of a block
+ * comment), skipping any decorator lines between the comment and the
+ * function. Levels are compared case-insensitively and come from the same
+ * per-function score and thresholds used for detected segments.
+ */
+
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { ComplexityScorer } from '../complexity/scorer';
+import { ComplexityLevelThresholds } from '../complexity/types';
+import { AnnotationCheck } from './types';
+
+const LEVELS = ['Very High', 'Low', 'Medium', 'High'];
+
+const ANNOTATION = new RegExp(`^(\\s*(?://+|#+|/?\\*+)\\s*Complexity:\\s*)(${LEVELS.join('|')})\\b`, 'i');
+
+const COMMENT_LINE = /^\s*(?:\/\/|#|\/\*|\*)/;
+
+/** Python and TypeScript decorators, e.g. @app.route("/") or @Get() */
+const DECORATOR_LINE = /^\s*@/;
+
+export class AnnotationVerifier {
+    /**
+     * Check every annotated function in a file
+     *
+     * @param sourceCode - Full file contents
+     * @param filename - File name for language detection
+     * @param levels - Level thresholds for the file, defaults to the built-in bands
+     * @returns One check per annotated function, in file order
+     */
+    static verify(sourceCode: string, filename: string, levels?: ComplexityLevelThresholds): AnnotationCheck[] {
+        const lines = sourceCode.split('\n');
+        const functions = ComplexityAnalyzer.forFile(filename).analyze(sourceCode).functions
+            .filter(func => func.name !== '*global*')
+            .sort((a, b) => a.startLine - b.startLine);
+
+        const checks: AnnotationCheck[] = [];
+        for (const func of functions) {
+            const annotation = this.findAnnotation(lines, func.startLine);
+            if (!annotation) {
+                continue;
+            }
+
+            const score = ComplexityScorer.scoreFunction(func).score;
+            const computed = ComplexityScorer.getComplexityLevel(score, levels);
+            checks.push({
+                functionName: func.name,
+                functionLine: func.startLine,
+                ...annotation,
+                computed,
+                score,
+                matches: annotation.annotated.toLowerCase() === computed.toLowerCase()
+            });
+        }
+
+        return checks;
+    }
+
+    /**
+     * Rewrite stale annotations to their computed level
+     *
+     * @param sourceCode - File contents the checks were made on
+     * @param checks - Checks from verify(); matching ones are left alone
+     */
+    static fix(sourceCode: string, checks: AnnotationCheck[]): string {
+        const lines = sourceCode.split('\n');
+        for (const check of checks.filter(c => !c.matches)) {
+            const line = lines[check.line];
+            lines[check.line] = line.slice(0, check.startColumn) + check.computed + line.slice(check.endColumn);
+        }
+        return lines.join('\n');
+    }
+
+    /**
+     * Find the annotation in the comment block directly above a line,
+     * looking past the function's decorators
+     */
+    private static findAnnotation(lines: string[], functionLine: number):
+        Pick<AnnotationCheck, 'line' | 'startColumn' | 'endColumn' | 'annotated'> | undefined {
+        let start = functionLine - 1;
+        while (start >= 0 && DECORATOR_LINE.test(lines[start])) {
+            start--;
+        }
+
+        for (let i = start; i >= 0 && COMMENT_LINE.test(lines[i]); i--) {
+            const match = ANNOTATION.exec(lines[i]);
+            if (match) {
+                return {
+                    line: i,
+                    startColumn: match[1].length,
+                    endColumn: match[1].length + match[2].length,
+                    annotated: match[2]
+                };
+            }
+        }
+        return undefined;
+    }
+}
@@ -0,0 +1,29 @@
+/**
+ * Annotations Module
+ *
+ * Verifies "Complexity: <level>" doc comments against computed complexity
+ *
+ * @module annotations
+ */
+
+export { AnnotationVerifier } from './annotationVerifier';
+
+export type { AnnotationCheck } from './types';
+
+/**
+ * Quick API: Find stale complexity annotations in a file
+ *
+ * @param sourceCode - Full file contents
+ * @param filename - File name for language detection
+ * @returns Checks whose annotation disagrees with the computed level
+ *
+ * @example
+ * ```typescript
+ * const stale = findStaleAnnotations(code, 'handlers.go');
+ * stale.forEach(c => console.log(`${c.functionName}: says ${c.annotated}, is ${c.computed}`));
+ * ```
+ */
+export function findStaleAnnotations(sourceCode: string, filename: string) {
+    const { AnnotationVerifier } = require('./annotationVerifier');
+    return AnnotationVerifier.verify(sourceCode, filename).filter((check: { matches: boolean }) => !check.matches);
+}
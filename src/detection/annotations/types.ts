@@ -0,0 +1,32 @@
+/**
+ * Type definitions for complexity annotations
+ */
+
+/**
+ * A "Complexity: <level>" doc comment compared with the function's computed level
+ */
+export interface AnnotationCheck {
+    functionName: string;
+
+    /** Line of the function (0-indexed) */
+    functionLine: number;
+
+    /** Line of the annotation comment (0-indexed) */
+    line: number;
+
+    /** Columns of the level text in the comment, end exclusive */
+    startColumn: number;
+    endColumn: number;
+
+    /** Level as written, e.g. 'Medium' */
+    annotated: string;
+
+    /** Level computed from the function's score and the file's thresholds */
+    computed: string;
+
+    /** Complexity score (1-10) behind the computed level */
+    score: number;
+
+    /** Whether the annotation agrees with the computed level */
+    matches: boolean;
+}
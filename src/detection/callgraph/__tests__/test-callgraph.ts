@@ -0,0 +1,162 @@
+/**
+ * Test for CallGraphBuilder
+ *
+ * Run with: npx ts-node src/detection/callgraph/__tests__/test-callgraph.ts
+ */
+
+import { CallGraphBuilder } from '../callGraphBuilder';
+import { createChecks } from '../../../__tests__/checks';
+
+const handlerGo = `package svc
+
+func Handle(w http.ResponseWriter, r *http.Request) {
+    if err := validate(r); err != nil {
+        fmt.Println(err)
+        return
+    }
+    store.Save(r.Context(), r.Body)
+}
+
+func validate(r *http.Request) error {
+    if r.Method != http.MethodPost {
+        return errMethod
+    }
+    return nil
+}
+
+func ping(n int) int {
+    if n > 0 {
+        return pong(n - 1)
+    }
+    return 0
+}
+
+func pong(n int) int {
+    if n > 0 {
+        return ping(n - 1)
+    }
+    return 0
+}
+`;
+
+const storeGo = `package svc
+
+func (s *Store) Save(ctx context.Context, body io.Reader) error {
+    for attempt := 0; attempt < 3; attempt++ {
+        if err := encode(body); err == nil {
+            return nil
+        }
+    }
+    return errSave
+}
+
+func encode(body io.Reader) error {
+    if body == nil {
+        return errEmpty
+    }
+    return nil
+}
+`;
+
+// Same name in another package: must not capture svc's calls
+const otherGo = `package web
+
+func validate(form Form) error {
+    if form.Name == "" {
+        return errName
+    }
+    return nil
+}
+`;
+
+// Qualified calls: imports, stdlib and methods with common names
+const apiGo = `package api
+
+import (
+    "encoding/json"
+    "net/http"
+
+    "example.com/shop/internal/codec"
+)
+
+func Serve(w http.ResponseWriter, r *http.Request) {
+    body, err := json.Marshal(r)
+    if err != nil {
+        http.Error(w, err.Error(), 500)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    codec.Marshal(body)
+}
+`;
+
+const codecGo = `package codec
+
+func Marshal(v []byte) []byte {
+    if v == nil {
+        return nil
+    }
+    return v
+}
+
+func (e *CodecError) Error() string { return e.msg }
+
+func (h Headers) Set(key, value string) { h[key] = value }
+`;
+
+function testCallGraph(): boolean {
+    console.log('\n=== Testing CallGraphBuilder ===\n');
+    const { check, finish } = createChecks();
+
+    const builder = new CallGraphBuilder();
+    builder.addFile('svc/handler.go', handlerGo);
+    builder.addFile('svc/store.go', storeGo);
+    builder.addFile('web/validate.go', otherGo, 'file:///ws/web/validate.go');
+    const graph = builder.build();
+
+    const node = (name: string, file?: string) =>
+        graph.nodes.find(n => n.functionName === name && (!file || n.filePath === file))!;
+    const calleeNames = (name: string) => node(name).callees.map(id => id.split('#')[1]).sort();
+
+    // Test 1: resolution
+    console.log('Test 1: Call resolution');
+    check('Handle calls (same file, same package, external dropped)', calleeNames('Handle'), ['Save:3', 'validate:11']);
+    check('Save calls encode', calleeNames('Save'), ['encode:12']);
+    check('web validate has no callers', graph.edges.filter(e => e.to === node('validate', 'web/validate.go').id).length, 0);
+    check('nodes keep the file location to open', node('validate', 'web/validate.go').uri, 'file:///ws/web/validate.go');
+
+    // Test 2: transitive complexity
+    console.log('\nTest 2: Transitive complexity');
+    const sum = (...names: string[]) => names.reduce((total, name) => total + node(name, name === 'validate' ? 'svc/handler.go' : undefined).cognitiveComplexity, 0);
+    check('Handle accumulates its call chain', node('Handle').transitiveComplexity, sum('Handle', 'validate', 'Save', 'encode'));
+    check('cycle counts each function once', node('ping').transitiveComplexity, sum('ping', 'pong'));
+    check('leaf is its own complexity', node('encode').transitiveComplexity, node('encode').cognitiveComplexity);
+    check('highest transitive complexity first', graph.nodes[0].functionName, 'Handle');
+
+    // Test 3: output formats
+    console.log('\nTest 3: Output');
+    const dot = CallGraphBuilder.toDot(graph);
+    check('DOT has the Handle -> Save edge', dot.includes('"svc/handler.go#Handle:3" -> "svc/store.go#Save:3";'), true);
+    const parsed = JSON.parse(CallGraphBuilder.toJson(graph));
+    check('JSON round-trips edges', parsed.edges.length, graph.edges.length);
+
+    // Test 4: qualified calls
+    console.log('\nTest 4: Qualified calls');
+    for (const withModule of [false, true]) {
+        const qualified = new CallGraphBuilder();
+        if (withModule) {
+            qualified.addGoModule('backend', 'example.com/shop');
+        }
+        const root = withModule ? 'backend/' : '';
+        qualified.addFile(`${root}api/serve.go`, apiGo);
+        qualified.addFile(`${root}internal/codec/codec.go`, codecGo);
+        const serve = qualified.build().nodes.find(n => n.functionName === 'Serve')!;
+        check(`only codec.Marshal resolves${withModule ? ' (module root)' : ''}`,
+            serve.callees, [`${root}internal/codec/codec.go#Marshal:3`]);
+    }
+
+    return finish();
+}
+
+// Run the test
+process.exit(testCallGraph() ? 0 : 1);
@@ -0,0 +1,309 @@
+/**
+ * Call Graph Builder
+ * Links functions across files by the names they call
+ *
+ * Calls are found lexically: an identifier followed by '(', with the name
+ * before a '.' kept as its qualifier. A plain call resolves to the function
+ * of that name in the same file, else in the same directory (a Go package),
+ * else to the only function of that name in the same language anywhere.
+ * Qualified calls (obj.method(, w.Header().Set() only resolve within the
+ * caller's file or directory, since a method name alone says nothing about
+ * the receiver. In Go, pkg.Func( where pkg is an import resolves in the
+ * package's directory: under a module added with addGoModule, else the
+ * directory the import path ends with. Standard library imports and imports
+ * matching no added directory are external. Ambiguous and external calls
+ * are dropped.
+ *
+ * Transitive complexity is the cognitive complexity of a function plus that
+ * of every function reachable from it, each counted once, so recursion and
+ * call cycles don't inflate it.
+ */
+
+import * as path from 'path';
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { Tokenizer } from '../complexity/tokenizer';
+import { Language } from '../complexity/types';
+import { goImports, isStandardLibrary } from '../../utils/goImports';
+import { CallGraph, CallGraphEdge, CallGraphNode } from './types';
+
+/**
+ * Keywords that can be followed by '(' without being calls
+ */
+const NON_CALLS = new Set([
+    'if', 'for', 'while', 'switch', 'return', 'catch', 'typeof', 'sizeof',
+    'await', 'new', 'super', 'elif', 'and', 'or', 'not', 'in', 'with', 'assert'
+]);
+
+const DEFINITION_KEYWORDS = new Set(['func', 'function', 'def']);
+
+/**
+ * A name called from a function body
+ */
+interface CallSite {
+    name: string;
+
+    /** Called as x.name( */
+    qualified: boolean;
+
+    /** The identifier before the '.', when there is one (not for chains like f().name) */
+    qualifier?: string;
+}
+
+/**
+ * A function with the names it calls, before resolution
+ */
+interface IndexedFunction {
+    node: CallGraphNode;
+    language: Language;
+    directory: string;
+    calls: CallSite[];
+
+    /** Go imports of the function's file: local name to import path */
+    imports: Map<string, string>;
+}
+
+export class CallGraphBuilder {
+    private functions: IndexedFunction[] = [];
+    private goModules: Array<{ root: string; modulePath: string }> = [];
+
+    /**
+     * Map a Go module's import paths to directories
+     *
+     * @param root - Directory of the go.mod, in the same form as added file paths ('.' for the top)
+     * @param modulePath - Module path from go.mod (e.g. example.com/shop)
+     */
+    addGoModule(root: string, modulePath: string): void {
+        this.goModules.push({ root: root.replace(/\\/g, '/'), modulePath });
+        // Nested modules take their import paths before the module around them
+        this.goModules.sort((a, b) => b.modulePath.length - a.modulePath.length);
+    }
+
+    /**
+     * Index every function in a file
+     *
+     * @param filePath - Path reported in the graph; its directory scopes resolution
+     * @param sourceCode - Full file contents
+     * @param uri - Where the file lives, kept on its nodes for opening them
+     */
+    addFile(filePath: string, sourceCode: string, uri?: string): void {
+        const analyzer = ComplexityAnalyzer.forFile(filePath);
+        const lines = sourceCode.split('\n');
+        const functions = analyzer.analyze(sourceCode).functions.filter(func => func.name !== '*global*');
+        const imports = analyzer.getLanguage() === Language.Go ? goImports(sourceCode) : new Map<string, string>();
+
+        for (const func of functions) {
+            // Calls inside nested functions belong to those functions
+            const nested = functions.filter(other => other !== func &&
+                other.startLine > func.startLine && other.endLine <= func.endLine);
+            const body = lines.slice(func.startLine, func.endLine + 1)
+                .map((line, i) => nested.some(n => func.startLine + i >= n.startLine && func.startLine + i <= n.endLine) ? '' : line);
+
+            this.functions.push({
+                node: {
+                    id: `${filePath}#${func.name}:${func.startLine + 1}`,
+                    filePath,
+                    uri,
+                    functionName: func.name,
+                    startLine: func.startLine,
+                    endLine: func.endLine,
+                    cognitiveComplexity: func.cognitiveComplexity,
+                    transitiveComplexity: func.cognitiveComplexity,
+                    callees: []
+                },
+                language: analyzer.getLanguage(),
+                directory: path.posix.dirname(filePath.replace(/\\/g, '/')),
+                calls: this.findCalls(body, func.name),
+                imports
+            });
+        }
+    }
+
+    /**
+     * Resolve calls and compute transitive complexity
+     *
+     * @returns Graph over every indexed function
+     */
+    build(): CallGraph {
+        const byName = new Map<string, IndexedFunction[]>();
+        for (const func of this.functions) {
+            byName.set(func.node.functionName, [...(byName.get(func.node.functionName) || []), func]);
+        }
+
+        const goDirectories = [...new Set(this.functions.filter(f => f.language === Language.Go).map(f => f.directory))];
+
+        const edges: CallGraphEdge[] = [];
+        for (const caller of this.functions) {
+            caller.node.callees = [];
+            for (const call of caller.calls) {
+                const callee = this.resolve(caller, call, byName.get(call.name) || [], goDirectories);
+                if (callee && !caller.node.callees.includes(callee.node.id)) {
+                    caller.node.callees.push(callee.node.id);
+                    edges.push({ from: caller.node.id, to: callee.node.id });
+                }
+            }
+        }
+
+        const nodes = new Map(this.functions.map(func => [func.node.id, func.node]));
+        for (const node of nodes.values()) {
+            node.transitiveComplexity = this.transitiveComplexity(node, nodes);
+        }
+
+        return {
+            nodes: [...nodes.values()].sort((a, b) => b.transitiveComplexity - a.transitiveComplexity),
+            edges
+        };
+    }
+
+    /**
+     * Render a graph in Graphviz DOT format
+     */
+    static toDot(graph: CallGraph): string {
+        const lines = ['digraph calls {', '    rankdir=LR;', '    node [shape=box];'];
+
+        for (const node of graph.nodes) {
+            const label = `${node.functionName}\n${path.basename(node.filePath)}:${node.startLine + 1}\n` +
+                `cognitive ${node.cognitiveComplexity} · transitive ${node.transitiveComplexity}`;
+            lines.push(`    ${JSON.stringify(node.id)} [label=${JSON.stringify(label)}];`);
+        }
+        for (const edge of graph.edges) {
+            lines.push(`    ${JSON.stringify(edge.from)} -> ${JSON.stringify(edge.to)};`);
+        }
+
+        lines.push('}');
+        return lines.join('\n');
+    }
+
+    /**
+     * Render a graph as JSON
+     */
+    static toJson(graph: CallGraph): string {
+        return JSON.stringify(graph, null, 2);
+    }
+
+    /**
+     * Names called from a function body, each name and qualifier once
+     */
+    private findCalls(body: string[], functionName: string): CallSite[] {
+        const calls = new Map<string, CallSite>();
+        let line = 0;
+        const recent: string[] = [];
+
+        for (const token of Tokenizer.generateTokens(body.join('\n'))) {
+            line += token.split('\n').length - 1;
+            if (!Tokenizer.isCodeToken(token)) {
+                continue;
+            }
+
+            // recent holds the last three code tokens: [before '.', '.', name]
+            const current = recent[recent.length - 1] ?? '';
+            const previous = recent[recent.length - 2] ?? '';
+
+            // A name on the first line followed by '(' is the function's own signature
+            const isSignature = line === 0 && current === functionName;
+            if (token === '(' && /^[A-Za-z_]\w*$/.test(current) && !NON_CALLS.has(current) &&
+                !DEFINITION_KEYWORDS.has(current) && !DEFINITION_KEYWORDS.has(previous) && !isSignature) {
+                const qualified = previous === '.';
+                const before = recent[recent.length - 3] ?? '';
+                const qualifier = qualified && /^[A-Za-z_]\w*$/.test(before) ? before : undefined;
+                calls.set(`${qualified ? (qualifier ?? '()') + '.' : ''}${current}`, { name: current, qualified, qualifier });
+            }
+
+            recent.push(token);
+            if (recent.length > 3) {
+                recent.shift();
+            }
+        }
+
+        return [...calls.values()];
+    }
+
+    /**
+     * Pick the function a call refers to, or undefined when external or ambiguous
+     */
+    private resolve(
+        caller: IndexedFunction,
+        call: CallSite,
+        candidates: IndexedFunction[],
+        goDirectories: string[]
+    ): IndexedFunction | undefined {
+        const sameLanguage = candidates.filter(c => c.language === caller.language ||
+            (this.isJavaScriptFamily(c.language) && this.isJavaScriptFamily(caller.language)));
+
+        // pkg.Func( on a Go import: only that package's directory, if it is in the workspace
+        const importPath = call.qualifier !== undefined ? caller.imports.get(call.qualifier) : undefined;
+        if (importPath !== undefined) {
+            const directory = this.goPackageDirectory(importPath, goDirectories);
+            const inPackage = directory === undefined ? [] : sameLanguage.filter(c => c.directory === directory);
+            return inPackage.length === 1 ? inPackage[0] : undefined;
+        }
+
+        const scopes = [
+            sameLanguage.filter(c => c.node.filePath === caller.node.filePath),
+            sameLanguage.filter(c => c.directory === caller.directory),
+            // A method name alone doesn't identify the receiver's type anywhere else
+            call.qualified ? [] : sameLanguage
+        ];
+        for (const scope of scopes) {
+            if (scope.length > 0) {
+                return scope.length === 1 ? scope[0] : undefined;
+            }
+        }
+        return undefined;
+    }
+
+    /**
+     * The added directory a Go import path refers to, or undefined for the
+     * standard library and packages outside the workspace
+     *
+     * @param goDirectories - Directories of every added Go file, collected once per build
+     */
+    private goPackageDirectory(importPath: string, goDirectories: string[]): string | undefined {
+        for (const module of this.goModules) {
+            if (importPath === module.modulePath || importPath.startsWith(module.modulePath + '/')) {
+                return path.posix.join(module.root, importPath.slice(module.modulePath.length + 1));
+            }
+        }
+
+        if (isStandardLibrary(importPath)) {
+            return undefined;
+        }
+
+        // The longest directory the import path ends with wins
+        const matches = goDirectories
+            .filter(dir => dir !== '.' && (importPath === dir || importPath.endsWith('/' + dir)))
+            .sort((a, b) => b.length - a.length);
+        if (matches.length === 0 || (matches.length > 1 && matches[0].length === matches[1].length)) {
+            return undefined;
+        }
+        return matches[0];
+    }
+
+    private isJavaScriptFamily(language: Language): boolean {
+        return language === Language.TypeScript || language === Language.JavaScript;
+    }
+
+    /**
+     * Sum cognitive complexity over everything reachable from a node
+     */
+    private transitiveComplexity(start: CallGraphNode, nodes: Map<string, CallGraphNode>): number {
+        const visited = new Set<string>([start.id]);
+        const stack = [...start.callees];
+        let total = start.cognitiveComplexity;
+
+        while (stack.length > 0) {
+            const id = stack.pop()!;
+            if (visited.has(id)) {
+                continue;
+            }
+            visited.add(id);
+
+            const node = nodes.get(id);
+            if (node) {
+                total += node.cognitiveComplexity;
+                stack.push(...node.callees);
+            }
+        }
+
+        return total;
+    }
+}
@@ -0,0 +1,36 @@
+/**
+ * Call Graph Module
+ *
+ * Cross-file call graph with complexity accumulated along call chains
+ *
+ * @module callgraph
+ */
+
+export { CallGraphBuilder } from './callGraphBuilder';
+
+export type {
+    CallGraph,
+    CallGraphNode,
+    CallGraphEdge
+} from './types';
+
+/**
+ * Quick API: Build a call graph over a set of files
+ *
+ * @param files - Map of file path to contents
+ * @returns Graph with nodes ordered by transitive complexity
+ *
+ * @example
+ * ```typescript
+ * const graph = buildCallGraph(new Map([['svc/handler.go', a], ['svc/store.go', b]]));
+ * graph.nodes.slice(0, 5).forEach(n => console.log(`${n.functionName}: ${n.transitiveComplexity}`));
+ * ```
+ */
+export function buildCallGraph(files: Map<string, string>) {
+    const { CallGraphBuilder } = require('./callGraphBuilder');
+    const builder = new CallGraphBuilder();
+    for (const [filePath, sourceCode] of files) {
+        builder.addFile(filePath, sourceCode);
+    }
+    return builder.build();
+}
@@ -0,0 +1,51 @@
+/**
+ * Type definitions for the call graph
+ */
+
+/**
+ * A function and the complexity it accumulates through its callees
+ */
+export interface CallGraphNode {
+    /** Unique ID: "<file>#<function>:<line>" */
+    id: string;
+
+    filePath: string;
+
+    /** Where the file lives, when passed to addFile (e.g. a file URI), for opening it */
+    uri?: string;
+
+    functionName: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    /** Ending line (0-indexed, inclusive) */
+    endLine: number;
+
+    /** The function's own cognitive complexity */
+    cognitiveComplexity: number;
+
+    /** Own complexity plus that of every function reachable through calls, each counted once */
+    transitiveComplexity: number;
+
+    /** IDs of functions called directly */
+    callees: string[];
+}
+
+/**
+ * A call from one function to another
+ */
+export interface CallGraphEdge {
+    from: string;
+    to: string;
+}
+
+/**
+ * Call graph over every added file
+ */
+export interface CallGraph {
+    /** Nodes, highest transitive complexity first */
+    nodes: CallGraphNode[];
+
+    edges: CallGraphEdge[];
+}
@@ -6,6 +6,7 @@ import { ComplexityAnalyzer } from './complexity/analyzer';
 import { FunctionInfo, Language } from './complexity/types';
 import { FunctionBoundaryDetector } from './complexity/functionBoundaryDetector';
 import { ShadowMetadataManager } from '../tracking/shadowMetadataManager';
+import { ComplexityThresholds } from '../utils/complexityThresholds';
 
 /**
  * Represents a document snapshot at a point in time
@@ -41,7 +42,9 @@ export interface DetectedBlock {
     complexityScore?: number; // 1-10 score indicating code complexity
     complexityData?: {
         ccn: number;
+        cognitive: number;
         nloc: number;
+        maintainabilityIndex: number;
         level: string;
     };
     functions?: FunctionInfo[]; // Phase 2: Detected functions within this block
@@ -54,6 +57,11 @@ export interface DetectedBlock {
 export class ChangeDetector {
     private _shadowDocuments: Map<string, DocumentSnapshot> = new Map();
     private _metadataManager?: ShadowMetadataManager;
+    private _complexityThresholds: ComplexityThresholds;
+
+    constructor(complexityThresholds: ComplexityThresholds) {
+        this._complexityThresholds = complexityThresholds;
+    }
 
     /**
      * Set metadata manager for tracking shadow state lifecycle
@@ -364,13 +372,18 @@ export class ChangeDetector {
                     const complexityScore = scoreResult.score;
                     const complexityData = {
                         ccn: scoreResult.ccn,
+                        cognitive: scoreResult.cognitive,
                         nloc: scoreResult.nloc,
-                        level: ComplexityScorer.getComplexityLevel(scoreResult.score)
+                        maintainabilityIndex: scoreResult.maintainabilityIndex,
+                        level: ComplexityScorer.getComplexityLevel(
+                            scoreResult.score,
+                            this._complexityThresholds.forFile(document.fileName)
+                        )
                     };
 
                     if (functions && functions.length > 0) {
                         Logger.debug(`  → Complexity: Score=${scoreResult.score}/10, CCN=${scoreResult.ccn}, NLOC=${scoreResult.nloc}, Functions=1`);
-                        Logger.debug(`    • ${functions[0].name}: CCN=${functions[0].cyclomaticComplexity}`);
+                        Logger.debug(`    • ${functions[0].name}: CCN=${functions[0].cyclomaticComplexity}, Cognitive=${functions[0].cognitiveComplexity}`);
                     } else {
                         Logger.debug(`  → Complexity: Score=${scoreResult.score}/10, CCN=${scoreResult.ccn}, NLOC=${scoreResult.nloc}`);
                     }
@@ -519,16 +532,9 @@ export class ChangeDetector {
             }
 
             // Score this specific function
-            const functionAnalysis = {
-                totalCCN: func.cyclomaticComplexity,
-                nloc: func.nloc,
-                tokenCount: func.tokenCount,
-                decisionPoints: func.cyclomaticComplexity - 1,
-                functions: [func]
-            };
-            const scoreResult = ComplexityScorer.scoreAnalysis(functionAnalysis);
+            const scoreResult = ComplexityScorer.scoreFunction(func);
 
-            Logger.debug(`    • ${func.name}: CCN=${func.cyclomaticComplexity}, Score=${scoreResult.score}/10, Lines=${functionStart}-${functionEnd}`);
+            Logger.debug(`    • ${func.name}: CCN=${func.cyclomaticComplexity}, Cognitive=${func.cognitiveComplexity}, Score=${scoreResult.score}/10, Lines=${functionStart}-${functionEnd}`);
 
             blocks.push({
                 startLine: functionStart,
@@ -542,8 +548,13 @@ export class ChangeDetector {
                 complexityScore: scoreResult.score,
                 complexityData: {
                     ccn: func.cyclomaticComplexity,
+                    cognitive: func.cognitiveComplexity,
                     nloc: func.nloc,
-                    level: ComplexityScorer.getComplexityLevel(scoreResult.score)
+                    maintainabilityIndex: scoreResult.maintainabilityIndex,
+                    level: ComplexityScorer.getComplexityLevel(
+                        scoreResult.score,
+                        this._complexityThresholds.forFile(document.fileName)
+                    )
                 },
                 functions: [func]
             });
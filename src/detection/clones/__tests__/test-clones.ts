@@ -0,0 +1,113 @@
+/**
+ * Test for CloneDetector
+ *
+ * Run with: npx ts-node src/detection/clones/__tests__/test-clones.ts
+ */
+
+import { CloneDetector } from '../cloneDetector';
+import { createChecks } from '../../../__tests__/checks';
+
+// Same handler shape with renamed identifiers and different literals
+const usersGo = `package api
+
+func usersHandler(w http.ResponseWriter, r *http.Request) {
+    id := r.URL.Query().Get("id")
+    if id == "" {
+        http.Error(w, "missing id", http.StatusBadRequest)
+        return
+    }
+    user, err := store.FindUser(r.Context(), id)
+    if err != nil {
+        http.Error(w, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(user)
+}
+
+func health(w http.ResponseWriter, r *http.Request) {
+    w.WriteHeader(200)
+}
+`;
+
+const ordersGo = `package api
+
+func ordersHandler(rw http.ResponseWriter, req *http.Request) {
+    orderID := req.URL.Query().Get("order")
+    if orderID == "" {
+        http.Error(rw, "missing order", http.StatusBadRequest)
+        return
+    }
+    order, err := store.FindOrder(req.Context(), orderID)
+    if err != nil {
+        http.Error(rw, err.Error(), http.StatusInternalServerError)
+        return
+    }
+    rw.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(rw).Encode(order)
+}
+
+func sumPrices(items []Item) (total int) {
+    for _, item := range items {
+        if item.Discounted {
+            total += item.Price * 9 / 10
+        } else {
+            total += item.Price
+        }
+    }
+    for i := 0; i < len(items); i++ {
+        items[i].Seen = true
+    }
+    return total
+}
+`;
+
+function testCloneDetector(): boolean {
+    console.log('\n=== Testing CloneDetector ===\n');
+    const { ok: check, finish } = createChecks();
+
+    // Test 1: renamed copy across files
+    console.log('Test 1: Renamed handler copied across files');
+    const detector = new CloneDetector({ minTokens: 30 });
+    detector.addFile('api/users.go', usersGo);
+    detector.addFile('api/orders.go', ordersGo);
+    const classes = detector.detect();
+
+    for (const cloneClass of classes) {
+        const members = cloneClass.fragments
+            .map(f => `${f.filePath}:${f.startLine + 1} ${f.functionName}`)
+            .join(', ');
+        console.log(`  ${cloneClass.fragments.length} copies, ~${cloneClass.tokenCount} tokens, similarity ${cloneClass.similarity.toFixed(2)}: ${members}`);
+    }
+
+    check('exactly one clone class', classes.length === 1);
+    const names = classes[0]?.fragments.map(f => f.functionName).sort().join(',');
+    check('class contains both handlers', names === 'ordersHandler,usersHandler');
+    check('renamed copy is an exact normalized match', classes[0]?.similarity === 1);
+    check('duplicated tokens equal one copy', classes[0]?.duplicatedTokens === classes[0]?.tokenCount);
+
+    // Test 2: minimum token length
+    console.log('\nTest 2: Minimum token length filters small functions');
+    const strict = new CloneDetector({ minTokens: 500 });
+    strict.addFile('api/users.go', usersGo);
+    strict.addFile('api/orders.go', ordersGo);
+    check('no clones above 500 tokens', strict.detect().length === 0);
+
+    // Test 3: near-miss with an extra statement
+    console.log('\nTest 3: Near-miss copy with an extra statement');
+    const edited = usersGo.replace(
+        '    w.Header().Set("Content-Type", "application/json")',
+        '    log.Printf("found user %s", id)\n    w.Header().Set("Content-Type", "application/json")'
+    );
+    const loose = new CloneDetector({ minTokens: 30, minSimilarity: 0.7 });
+    loose.addFile('api/users.go', edited);
+    loose.addFile('api/orders.go', ordersGo);
+    const nearMiss = loose.detect();
+    check('near-miss is grouped below similarity 1',
+        nearMiss.length === 1 && nearMiss[0].similarity < 1 && nearMiss[0].similarity >= 0.7);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testCloneDetector() ? 0 : 1);
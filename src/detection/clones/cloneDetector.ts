@@ -0,0 +1,190 @@
+/**
+ * Clone Detector
+ * Finds near-duplicate functions across files
+ *
+ * Each function's tokens are normalized so that renamed identifiers and
+ * changed literals still match (if (count > 10) and if (total > 99) look the
+ * same). Functions are then compared by the Jaccard similarity of their
+ * normalized token 5-grams, and every pair above the threshold is merged
+ * into a clone class.
+ */
+
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { HalsteadCalculator } from '../complexity/halstead';
+import { Tokenizer } from '../complexity/tokenizer';
+import { CloneClass, CloneDetectorOptions, CloneFragment } from './types';
+
+/**
+ * Length of the token n-grams used for similarity
+ */
+const SHINGLE_SIZE = 5;
+
+const DEFAULT_OPTIONS: Required<CloneDetectorOptions> = {
+    minTokens: 50,
+    minSimilarity: 0.85
+};
+
+/**
+ * A function's normalized tokens, ready for comparison
+ */
+interface IndexedFragment {
+    fragment: CloneFragment;
+    shingles: Set<string>;
+}
+
+export class CloneDetector {
+    private options: Required<CloneDetectorOptions>;
+    private fragments: IndexedFragment[] = [];
+
+    constructor(options: CloneDetectorOptions = {}) {
+        this.options = { ...DEFAULT_OPTIONS, ...options };
+    }
+
+    /**
+     * Index every function in a file
+     *
+     * @param filePath - Path reported in clone fragments
+     * @param sourceCode - Full file contents
+     */
+    addFile(filePath: string, sourceCode: string): void {
+        const analyzer = ComplexityAnalyzer.forFile(filePath);
+        const halstead = new HalsteadCalculator(analyzer.getLanguage());
+        const lines = sourceCode.split('\n');
+
+        for (const func of analyzer.analyze(sourceCode).functions) {
+            if (func.name === '*global*') {
+                continue;
+            }
+
+            const body = lines.slice(func.startLine, func.endLine + 1).join('\n');
+            const tokens = Tokenizer.filterCodeTokens(Tokenizer.generateTokens(body))
+                .map(token => this.normalize(token, halstead));
+
+            if (tokens.length < this.options.minTokens) {
+                continue;
+            }
+
+            this.fragments.push({
+                fragment: {
+                    filePath,
+                    functionName: func.name,
+                    startLine: func.startLine,
+                    endLine: func.endLine,
+                    tokenCount: tokens.length
+                },
+                shingles: this.shingle(tokens)
+            });
+        }
+    }
+
+    /**
+     * Group indexed functions into clone classes
+     *
+     * @returns Clone classes, most duplicated tokens first
+     */
+    detect(): CloneClass[] {
+        const parent = this.fragments.map((_, i) => i);
+        const find = (i: number): number => {
+            while (parent[i] !== i) {
+                parent[i] = parent[parent[i]];
+                i = parent[i];
+            }
+            return i;
+        };
+
+        // Lowest linking similarity per class root
+        const linkSimilarity = new Map<number, number>();
+
+        for (let i = 0; i < this.fragments.length; i++) {
+            for (let j = i + 1; j < this.fragments.length; j++) {
+                const a = this.fragments[i].shingles;
+                const b = this.fragments[j].shingles;
+
+                // Jaccard can't exceed the size ratio, so skip pairs that can't match
+                if (Math.min(a.size, b.size) / Math.max(a.size, b.size) < this.options.minSimilarity) {
+                    continue;
+                }
+
+                const similarity = this.jaccard(a, b);
+                if (similarity < this.options.minSimilarity) {
+                    continue;
+                }
+
+                const rootI = find(i);
+                const rootJ = find(j);
+                if (rootI === rootJ) {
+                    continue;
+                }
+
+                const lowest = Math.min(
+                    similarity,
+                    linkSimilarity.get(rootI) ?? 1,
+                    linkSimilarity.get(rootJ) ?? 1
+                );
+                parent[rootJ] = rootI;
+                linkSimilarity.delete(rootJ);
+                linkSimilarity.set(rootI, lowest);
+            }
+        }
+
+        const groups = new Map<number, CloneFragment[]>();
+        this.fragments.forEach((indexed, i) => {
+            const root = find(i);
+            if (!linkSimilarity.has(root)) {
+                return;
+            }
+            const group = groups.get(root) || [];
+            group.push(indexed.fragment);
+            groups.set(root, group);
+        });
+
+        const classes: CloneClass[] = [];
+        for (const [root, fragments] of groups) {
+            fragments.sort((a, b) =>
+                a.filePath.localeCompare(b.filePath) || a.startLine - b.startLine
+            );
+            const totalTokens = fragments.reduce((sum, f) => sum + f.tokenCount, 0);
+            const tokenCount = Math.round(totalTokens / fragments.length);
+
+            classes.push({
+                fragments,
+                similarity: linkSimilarity.get(root)!,
+                tokenCount,
+                duplicatedTokens: totalTokens - tokenCount
+            });
+        }
+
+        return classes.sort((a, b) => b.duplicatedTokens - a.duplicatedTokens);
+    }
+
+    /**
+     * Replace identifiers and literals with placeholders; keep keywords and symbols
+     */
+    private normalize(token: string, halstead: HalsteadCalculator): string {
+        if (/^["'`]/.test(token) || /^\d/.test(token)) {
+            return '$lit';
+        }
+        if (/^\w/.test(token) && !halstead.isKeyword(token)) {
+            return '$id';
+        }
+        return token;
+    }
+
+    private shingle(tokens: string[]): Set<string> {
+        const shingles = new Set<string>();
+        for (let i = 0; i + SHINGLE_SIZE <= tokens.length; i++) {
+            shingles.add(tokens.slice(i, i + SHINGLE_SIZE).join(' '));
+        }
+        return shingles;
+    }
+
+    private jaccard(a: Set<string>, b: Set<string>): number {
+        let shared = 0;
+        for (const shingle of a) {
+            if (b.has(shingle)) {
+                shared++;
+            }
+        }
+        return shared / (a.size + b.size - shared);
+    }
+}
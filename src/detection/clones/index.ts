@@ -0,0 +1,39 @@
+/**
+ * Clone Detection Module
+ *
+ * Finds near-duplicate functions across files and groups them into clone classes
+ *
+ * @module clones
+ */
+
+import type { CloneDetectorOptions } from './types';
+
+export { CloneDetector } from './cloneDetector';
+
+export type {
+    CloneFragment,
+    CloneClass,
+    CloneDetectorOptions
+} from './types';
+
+/**
+ * Quick API: Find clones across a set of files
+ *
+ * @param files - Map of file path to contents
+ * @param options - Minimum token length and similarity
+ * @returns Clone classes, most duplicated tokens first
+ *
+ * @example
+ * ```typescript
+ * const classes = findClones(new Map([['a.go', a], ['b.go', b]]), { minTokens: 40 });
+ * classes.forEach(c => console.log(`${c.fragments.length} copies, ~${c.tokenCount} tokens`));
+ * ```
+ */
+export function findClones(files: Map<string, string>, options: CloneDetectorOptions = {}) {
+    const { CloneDetector } = require('./cloneDetector');
+    const detector = new CloneDetector(options);
+    for (const [filePath, sourceCode] of files) {
+        detector.addFile(filePath, sourceCode);
+    }
+    return detector.detect();
+}
@@ -0,0 +1,51 @@
+/**
+ * Type definitions for clone detection
+ */
+
+/**
+ * A function that takes part in a clone class
+ */
+export interface CloneFragment {
+    /** File containing the function */
+    filePath: string;
+
+    /** Function name */
+    functionName: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    /** Ending line (0-indexed, inclusive) */
+    endLine: number;
+
+    /** Number of code tokens in the function */
+    tokenCount: number;
+}
+
+/**
+ * A group of functions that are near-duplicates of each other
+ */
+export interface CloneClass {
+    /** Member functions, ordered by file and line */
+    fragments: CloneFragment[];
+
+    /** Lowest similarity (0-1) among the pairs that link the class together */
+    similarity: number;
+
+    /** Average token count of the members */
+    tokenCount: number;
+
+    /** Tokens that would go away if the class were reduced to a single copy */
+    duplicatedTokens: number;
+}
+
+/**
+ * Clone detector configuration
+ */
+export interface CloneDetectorOptions {
+    /** Functions with fewer code tokens are ignored */
+    minTokens?: number;
+
+    /** Minimum similarity (0-1) for two functions to count as clones */
+    minSimilarity?: number;
+}
@@ -0,0 +1,163 @@
+/**
+ * Test for RevisionComparer
+ *
+ * Run with: npx ts-node src/detection/compare/__tests__/test-compare.ts
+ */
+
+import { execFileSync } from 'child_process';
+import * as fs from 'fs';
+import * as os from 'os';
+import * as path from 'path';
+import { RevisionComparer } from '../revisionComparer';
+import { readFileAtRef, resolveRef } from '../gitRevisions';
+import { compareRevisions } from '../index';
+import { createChecks } from '../../../__tests__/checks';
+
+const handlerBefore = `package api
+
+func Get(id string) error {
+    if id == "" {
+        return errEmpty
+    }
+    return nil
+}
+
+func List(ids []string) int {
+    count := 0
+    for _, id := range ids {
+        if id != "" {
+            if len(id) > 3 {
+                count++
+            }
+        }
+    }
+    return count
+}
+
+func legacy() {
+}
+`;
+
+const handlerAfter = `package api
+
+func Get(id string) error {
+    if id == "" || len(id) > 64 {
+        return errEmpty
+    }
+    for _, c := range id {
+        if c == ' ' {
+            return errSpace
+        }
+    }
+    return nil
+}
+
+func List(ids []string) int {
+    return len(ids)
+}
+
+func Delete(id string) error {
+    if id == "" {
+        return errEmpty
+    }
+    return nil
+}
+`;
+
+const storeAfter = `package store
+
+func Save() error {
+    return nil
+}
+`;
+
+/**
+ * Run with LANG/LC_ALL set, so git would print translated messages
+ */
+async function withLocale<T>(locale: string, run: () => Promise<T>): Promise<T> {
+    const saved = { LANG: process.env.LANG, LC_ALL: process.env.LC_ALL };
+    process.env.LANG = process.env.LC_ALL = locale;
+    try {
+        return await run();
+    } finally {
+        for (const [name, value] of Object.entries(saved)) {
+            if (value === undefined) {
+                delete process.env[name];
+            } else {
+                process.env[name] = value;
+            }
+        }
+    }
+}
+
+async function testRevisionComparer(): Promise<boolean> {
+    console.log('\n=== Testing RevisionComparer ===\n');
+    const { check, finish } = createChecks();
+
+    const comparer = new RevisionComparer('v1.0.0', 'HEAD');
+    comparer.addFile('api/handler.go', handlerBefore, handlerAfter);
+    comparer.addFile('store/store.go', undefined, storeAfter);
+    const comparison = comparer.compare();
+    const change = (name: string) => comparison.changes.find(c => c.functionName === name);
+
+    // Test 1: function changes
+    console.log('Test 1: Functions');
+    check('kinds', comparison.changes.map(c => `${c.functionName}:${c.kind}`),
+        ['Get:regressed', 'Delete:added', 'legacy:removed', 'Save:added', 'List:improved']);
+    check('Get cognitive', [change('Get')?.before?.cognitiveComplexity, change('Get')?.after?.cognitiveComplexity], [1, 5]);
+    check('List delta', change('List')?.cognitiveDelta, -6);
+
+    // Test 2: package and total deltas
+    console.log('\nTest 2: Totals');
+    check('totals', [comparison.totalBefore, comparison.totalAfter], [7, 6]);
+    check('packages', comparison.packages.map(p => `${p.directory}:${p.delta}`), ['api:-1']);
+    check('file count', comparison.fileCount, 2);
+
+    // Test 3: Markdown
+    console.log('\nTest 3: Markdown');
+    const markdown = RevisionComparer.toMarkdown(comparison);
+    check('headline', markdown.split('\n')[2], 'Cognitive complexity of the 2 changed files: 7 → 6 (-1)');
+    check('regression row', markdown.includes('| `Get` | api/handler.go:3 | 1 → 5 | '), true);
+    check('unchanged revisions', RevisionComparer.toMarkdown(new RevisionComparer('a', 'b').compare()).includes('No function changed complexity.'), true);
+
+    // Test 4: git access
+    console.log('\nTest 4: Git');
+    const repo = fs.mkdtempSync(path.join(os.tmpdir(), 'voight-compare-'));
+    const run = (...args: string[]) => execFileSync('git', ['-c', 'user.name=test', '-c', 'user.email=test@example.com', ...args], { cwd: repo });
+    try {
+        run('init', '-q');
+        fs.writeFileSync(path.join(repo, 'a.go'), 'package a\n');
+        run('add', '.');
+        run('commit', '-q', '-m', 'initial');
+        fs.writeFileSync(path.join(repo, 'b.go'), 'package b\n');
+
+        const head = await resolveRef(repo, 'HEAD');
+        check('refs resolve to commits', /^[0-9a-f]{40}$/.test(head), true);
+        const rejected = await resolveRef(repo, '--output=/tmp/x').then(() => false, () => true);
+        check('option-like refs are rejected', rejected, true);
+        check('file at ref', await readFileAtRef(repo, head, 'a.go'), 'package a\n');
+        check('missing at ref (on disk too)', await readFileAtRef(repo, head, 'b.go'), undefined);
+        const failed = await readFileAtRef(repo, 'no-such-ref', 'a.go').then(() => false, () => true);
+        check('other git errors are thrown', failed, true);
+        check('missing at ref under a non-English locale', await withLocale('de_DE.UTF-8', () => readFileAtRef(repo, head, 'b.go')), undefined);
+
+        // Build output, generated code and non-source files are left out of the comparison
+        fs.mkdirSync(path.join(repo, 'dist'));
+        fs.writeFileSync(path.join(repo, 'dist', 'bundle.js'), 'function bundle() {}\n');
+        fs.writeFileSync(path.join(repo, 'enum.go'), '// Code generated by stringer; DO NOT EDIT.\n\npackage a\n');
+        fs.writeFileSync(path.join(repo, 'NOTES.md'), '# notes\n');
+        run('add', '.');
+        run('commit', '-q', '-m', 'second');
+        check('filtered by default', (await compareRevisions(repo, 'HEAD~1', 'HEAD')).fileCount, 1);
+        const included: string[] = [];
+        await compareRevisions(repo, 'HEAD~1', 'HEAD', { include: filePath => included.push(filePath) > 0 });
+        check('include decides on the remaining sources', included.sort(), ['b.go', 'enum.go']);
+    } finally {
+        fs.rmSync(repo, { recursive: true, force: true });
+    }
+
+    return finish();
+}
+
+// Run the test
+testRevisionComparer().then(ok => process.exit(ok ? 0 : 1));
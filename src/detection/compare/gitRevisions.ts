@@ -0,0 +1,59 @@
+/**
+ * Git Revisions
+ * Reads the files that differ between two refs and their contents at each
+ */
+
+import { execFile } from 'child_process';
+
+function git(repoRoot: string, args: string[]): Promise<string> {
+    return new Promise((resolve, reject) => {
+        execFile('git', args, { cwd: repoRoot, maxBuffer: 64 * 1024 * 1024 }, (error, stdout, stderr) => {
+            if (error) {
+                reject(Object.assign(error, { stderr }));
+                return;
+            }
+            resolve(stdout);
+        });
+    });
+}
+
+/**
+ * Resolve a user-supplied ref to a commit
+ * Anything that isn't a ref (including option-like input such as --output=x) is rejected
+ *
+ * @returns Full commit hash, safe to pass to other git commands
+ */
+export async function resolveRef(repoRoot: string, ref: string): Promise<string> {
+    const output = await git(repoRoot, ['rev-parse', '--verify', '--end-of-options', `${ref}^{commit}`]);
+    return output.trim();
+}
+
+/**
+ * List files that differ between two refs
+ *
+ * @param repoRoot - Directory to run git in; paths are relative to it
+ * @param baseRef - Commit from resolveRef
+ * @param targetRef - Commit from resolveRef
+ * @returns Paths relative to repoRoot, renames listed as a deletion and an addition
+ */
+export async function listChangedFiles(repoRoot: string, baseRef: string, targetRef: string): Promise<string[]> {
+    const output = await git(repoRoot, ['diff', '--name-only', '--no-renames', '--relative', baseRef, targetRef, '--']);
+    return output.split('\n').map(line => line.trim()).filter(line => line);
+}
+
+/**
+ * Read a file as of a ref
+ *
+ * @param repoRoot - Directory the path is relative to
+ * @param ref - Commit from resolveRef
+ * @returns Contents, or undefined when the file does not exist at that ref
+ * @throws When git fails, so a failed read isn't taken for a deletion
+ */
+export async function readFileAtRef(repoRoot: string, ref: string, filePath: string): Promise<string | undefined> {
+    // Existence comes from the tree listing rather than git show's (localized) error message
+    const listed = await git(repoRoot, ['--literal-pathspecs', 'ls-tree', '--name-only', ref, '--', filePath]);
+    if (!listed.trim()) {
+        return undefined;
+    }
+    return git(repoRoot, ['show', `${ref}:./${filePath}`]);
+}
@@ -0,0 +1,61 @@
+/**
+ * Compare Module
+ *
+ * Complexity diff between two git revisions
+ *
+ * @module compare
+ */
+
+import type { CompareOptions } from './types';
+
+export { RevisionComparer } from './revisionComparer';
+export { listChangedFiles, readFileAtRef, resolveRef } from './gitRevisions';
+
+export type {
+    CompareOptions,
+    RevisionComparison,
+    FunctionChange,
+    FunctionChangeKind,
+    FunctionSnapshot,
+    PackageDelta
+} from './types';
+
+/**
+ * Quick API: Compare two revisions of a git repository
+ *
+ * @param repoRoot - Repository (or subdirectory) to compare
+ * @param baseRef - Older ref, e.g. a release tag
+ * @param targetRef - Newer ref, e.g. HEAD
+ * @param options - Which changed files to analyze; supported sources outside build
+ *   output and dependencies, minus generated and vendored code, by default
+ * @returns Structured diff of the files that changed between the refs
+ *
+ * @example
+ * ```typescript
+ * const comparison = await compareRevisions(root, 'v1.4.0', 'HEAD');
+ * console.log(RevisionComparer.toMarkdown(comparison));
+ * ```
+ */
+export async function compareRevisions(repoRoot: string, baseRef: string, targetRef: string, options: CompareOptions = {}) {
+    const { RevisionComparer } = require('./revisionComparer');
+    const { listChangedFiles, readFileAtRef, resolveRef } = require('./gitRevisions');
+    const { SOURCE_EXTENSION, isInExcludedDirectory } = require('../../utils/sourcePaths');
+    const { classifyGenerated } = require('../../utils/generatedCode');
+    const include = options.include ?? ((filePath: string, content?: string) => classifyGenerated(filePath, content) === undefined);
+
+    const comparer = new RevisionComparer(baseRef, targetRef);
+    const base = await resolveRef(repoRoot, baseRef);
+    const target = await resolveRef(repoRoot, targetRef);
+
+    // Only changed files are analyzed; the rest contribute equally to both sides
+    const changedFiles: string[] = (await listChangedFiles(repoRoot, base, target))
+        .filter((filePath: string) => SOURCE_EXTENSION.test(filePath) && !isInExcludedDirectory(filePath));
+    for (const filePath of changedFiles) {
+        const before = await readFileAtRef(repoRoot, base, filePath);
+        const after = await readFileAtRef(repoRoot, target, filePath);
+        if (include(filePath, after ?? before)) {
+            comparer.addFile(filePath, before, after);
+        }
+    }
+    return comparer.compare();
+}
@@ -0,0 +1,185 @@
+/**
+ * Revision Comparer
+ * Diffs function complexity between two revisions of a set of files
+ *
+ * Only files that differ between the revisions need to be added: unchanged
+ * files contribute the same to both sides, so package and total deltas are
+ * the same as for the full tree. Functions are matched by file and name
+ * (the nth function of a name matches the nth), so a rename shows as one
+ * removal and one addition. A function regressed when its cognitive
+ * complexity rose, or stayed the same while its CCN rose.
+ */
+
+import * as path from 'path';
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { ComplexityScorer } from '../complexity/scorer';
+import { FunctionChange, FunctionSnapshot, PackageDelta, RevisionComparison } from './types';
+
+export class RevisionComparer {
+    private baseRef: string;
+    private targetRef: string;
+    private files: Array<{ filePath: string; before: FunctionSnapshot[]; after: FunctionSnapshot[] }> = [];
+
+    /**
+     * @param baseRef - Name of the older revision, for the report
+     * @param targetRef - Name of the newer revision, for the report
+     */
+    constructor(baseRef: string, targetRef: string) {
+        this.baseRef = baseRef;
+        this.targetRef = targetRef;
+    }
+
+    /**
+     * Add a file that differs between the revisions
+     *
+     * @param filePath - Repo-relative path; its directory is the package
+     * @param before - Contents at the base revision, undefined when the file was added
+     * @param after - Contents at the target revision, undefined when the file was deleted
+     */
+    addFile(filePath: string, before: string | undefined, after: string | undefined): void {
+        this.files.push({
+            filePath,
+            before: before === undefined ? [] : this.snapshot(filePath, before),
+            after: after === undefined ? [] : this.snapshot(filePath, after)
+        });
+    }
+
+    /**
+     * Match functions across the revisions and total the changes
+     */
+    compare(): RevisionComparison {
+        const changes: FunctionChange[] = [];
+        const packages = new Map<string, PackageDelta>();
+        let totalBefore = 0;
+        let totalAfter = 0;
+
+        for (const file of this.files) {
+            const before = this.byKey(file.before);
+            const after = this.byKey(file.after);
+
+            for (const [key, old] of before) {
+                const current = after.get(key);
+                if (!current) {
+                    changes.push({ kind: 'removed', filePath: file.filePath, functionName: old.functionName, before: old, cognitiveDelta: 0 - old.cognitiveComplexity });
+                    continue;
+                }
+
+                const cognitiveDelta = current.cognitiveComplexity - old.cognitiveComplexity;
+                const trend = cognitiveDelta || current.cyclomaticComplexity - old.cyclomaticComplexity;
+                if (trend !== 0) {
+                    changes.push({
+                        kind: trend > 0 ? 'regressed' : 'improved',
+                        filePath: file.filePath,
+                        functionName: current.functionName,
+                        before: old,
+                        after: current,
+                        cognitiveDelta
+                    });
+                }
+            }
+            for (const [key, current] of after) {
+                if (!before.has(key)) {
+                    changes.push({ kind: 'added', filePath: file.filePath, functionName: current.functionName, after: current, cognitiveDelta: current.cognitiveComplexity });
+                }
+            }
+
+            const fileBefore = file.before.reduce((sum, f) => sum + f.cognitiveComplexity, 0);
+            const fileAfter = file.after.reduce((sum, f) => sum + f.cognitiveComplexity, 0);
+            totalBefore += fileBefore;
+            totalAfter += fileAfter;
+
+            const directory = path.posix.dirname(file.filePath.replace(/\\/g, '/'));
+            const pkg = packages.get(directory) || { directory, before: 0, after: 0, delta: 0 };
+            pkg.before += fileBefore;
+            pkg.after += fileAfter;
+            pkg.delta = pkg.after - pkg.before;
+            packages.set(directory, pkg);
+        }
+
+        return {
+            baseRef: this.baseRef,
+            targetRef: this.targetRef,
+            fileCount: this.files.length,
+            changes: changes.sort((a, b) => b.cognitiveDelta - a.cognitiveDelta),
+            packages: [...packages.values()].filter(p => p.delta !== 0).sort((a, b) => b.delta - a.delta),
+            totalBefore,
+            totalAfter
+        };
+    }
+
+    /**
+     * Render a comparison as Markdown, for release notes and PR descriptions
+     */
+    static toMarkdown(comparison: RevisionComparison): string {
+        const signed = (n: number) => (n > 0 ? `+${n}` : `${n}`);
+        const net = comparison.totalAfter - comparison.totalBefore;
+        const lines = [
+            `## Complexity: ${comparison.baseRef} → ${comparison.targetRef}`,
+            '',
+            `Cognitive complexity of the ${comparison.fileCount} changed files: ` +
+                `${comparison.totalBefore} → ${comparison.totalAfter} (${signed(net)})`
+        ];
+
+        if (comparison.packages.length > 0) {
+            lines.push('', '### Packages', '', '| Package | Before | After | Change |', '|---------|--------|-------|--------|');
+            for (const pkg of comparison.packages) {
+                lines.push(`| \`${pkg.directory}\` | ${pkg.before} | ${pkg.after} | ${signed(pkg.delta)} |`);
+            }
+        }
+
+        const sections: Array<[FunctionChange['kind'], string]> = [
+            ['regressed', 'Regressed'], ['improved', 'Improved'], ['added', 'Added'], ['removed', 'Removed']
+        ];
+        for (const [kind, title] of sections) {
+            const changes = comparison.changes.filter(c => c.kind === kind);
+            if (changes.length === 0) {
+                continue;
+            }
+
+            lines.push('', `### ${title} (${changes.length})`, '', '| Function | File | Cognitive | CCN |', '|----------|------|-----------|-----|');
+            for (const change of changes) {
+                const at = change.after ?? change.before!;
+                const metric = (select: (f: FunctionSnapshot) => number) => change.before && change.after
+                    ? `${select(change.before)} → ${select(change.after)}`
+                    : `${select(at)}`;
+                lines.push(`| \`${change.functionName}\` | ${change.filePath}:${at.startLine + 1} | ` +
+                    `${metric(f => f.cognitiveComplexity)} | ${metric(f => f.cyclomaticComplexity)} |`);
+            }
+        }
+
+        if (comparison.changes.length === 0) {
+            lines.push('', 'No function changed complexity.');
+        }
+
+        return lines.join('\n') + '\n';
+    }
+
+    private snapshot(filePath: string, sourceCode: string): FunctionSnapshot[] {
+        return ComplexityAnalyzer.forFile(filePath).analyze(sourceCode).functions
+            .filter(func => func.name !== '*global*')
+            .sort((a, b) => a.startLine - b.startLine)
+            .map(func => ({
+                filePath,
+                functionName: func.name,
+                startLine: func.startLine,
+                cyclomaticComplexity: func.cyclomaticComplexity,
+                cognitiveComplexity: func.cognitiveComplexity,
+                nloc: func.nloc,
+                score: ComplexityScorer.scoreFunction(func).score
+            }));
+    }
+
+    /**
+     * Key functions by name and occurrence, so same-named functions match in order
+     */
+    private byKey(functions: FunctionSnapshot[]): Map<string, FunctionSnapshot> {
+        const seen = new Map<string, number>();
+        const keyed = new Map<string, FunctionSnapshot>();
+        for (const func of functions) {
+            const occurrence = seen.get(func.functionName) || 0;
+            seen.set(func.functionName, occurrence + 1);
+            keyed.set(`${func.functionName}#${occurrence}`, func);
+        }
+        return keyed;
+    }
+}
@@ -0,0 +1,86 @@
+/**
+ * Type definitions for comparing complexity between revisions
+ */
+
+/**
+ * Which changed files compareRevisions analyzes
+ */
+export interface CompareOptions {
+    /**
+     * Decide on a changed source file outside the excluded directories
+     * Defaults to skipping generated and vendored code
+     *
+     * @param filePath - Path relative to the repository root passed in
+     * @param content - Contents at the target ref, or at the base ref when deleted
+     */
+    include?: (filePath: string, content: string | undefined) => boolean;
+}
+
+/**
+ * A function's metrics at one revision
+ */
+export interface FunctionSnapshot {
+    filePath: string;
+    functionName: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    cyclomaticComplexity: number;
+    cognitiveComplexity: number;
+    nloc: number;
+
+    /** Complexity score (1-10) */
+    score: number;
+}
+
+export type FunctionChangeKind = 'added' | 'removed' | 'regressed' | 'improved';
+
+/**
+ * A function that differs between the two revisions
+ */
+export interface FunctionChange {
+    kind: FunctionChangeKind;
+    filePath: string;
+    functionName: string;
+
+    /** Metrics at the base revision (absent when added) */
+    before?: FunctionSnapshot;
+
+    /** Metrics at the target revision (absent when removed) */
+    after?: FunctionSnapshot;
+
+    /** Change in cognitive complexity, after minus before */
+    cognitiveDelta: number;
+}
+
+/**
+ * Net cognitive complexity change of one package (directory)
+ */
+export interface PackageDelta {
+    directory: string;
+    before: number;
+    after: number;
+    delta: number;
+}
+
+/**
+ * Structured diff of complexity between two revisions
+ */
+export interface RevisionComparison {
+    baseRef: string;
+    targetRef: string;
+
+    /** Files that differ between the revisions */
+    fileCount: number;
+
+    /** Changed functions, largest regression first */
+    changes: FunctionChange[];
+
+    /** Packages whose total changed, largest increase first */
+    packages: PackageDelta[];
+
+    /** Total cognitive complexity of the changed files at each revision */
+    totalBefore: number;
+    totalAfter: number;
+}
@@ -64,9 +64,9 @@ func lcsHandler(w http.ResponseWriter, r *http.Request) {
 }`;
 
     const goFunctions: FunctionInfo[] = [
-        { name: 'temperatureHandler', longName: 'temperatureHandler', cyclomaticComplexity: 7, nloc: 10, tokenCount: 50, parameterCount: 2, maxNestingDepth: 1, startLine: 0, endLine: 0 },
-        { name: 'runLengthEncodingHandler', longName: 'runLengthEncodingHandler', cyclomaticComplexity: 13, nloc: 20, tokenCount: 80, parameterCount: 2, maxNestingDepth: 2, startLine: 0, endLine: 0 },
-        { name: 'lcsHandler', longName: 'lcsHandler', cyclomaticComplexity: 35, nloc: 30, tokenCount: 100, parameterCount: 2, maxNestingDepth: 3, startLine: 0, endLine: 0 }
+        { name: 'temperatureHandler', longName: 'temperatureHandler', cyclomaticComplexity: 7, cognitiveComplexity: 0, nloc: 10, tokenCount: 50, parameterCount: 2, maxNestingDepth: 1, startLine: 0, endLine: 0 },
+        { name: 'runLengthEncodingHandler', longName: 'runLengthEncodingHandler', cyclomaticComplexity: 13, cognitiveComplexity: 0, nloc: 20, tokenCount: 80, parameterCount: 2, maxNestingDepth: 2, startLine: 0, endLine: 0 },
+        { name: 'lcsHandler', longName: 'lcsHandler', cyclomaticComplexity: 35, cognitiveComplexity: 0, nloc: 30, tokenCount: 100, parameterCount: 2, maxNestingDepth: 3, startLine: 0, endLine: 0 }
     ];
 
     const goBoundaries = FunctionBoundaryDetector.detectBoundaries(goCode, goFunctions, Language.Go);
@@ -106,9 +106,9 @@ const arrowFunction = (x: number) => {
 }`;
 
     const tsFunctions: FunctionInfo[] = [
-        { name: 'simpleFunction', longName: 'simpleFunction', cyclomaticComplexity: 2, nloc: 5, tokenCount: 20, parameterCount: 1, maxNestingDepth: 1, startLine: 0, endLine: 0 },
-        { name: 'complexFunction', longName: 'complexFunction', cyclomaticComplexity: 5, nloc: 10, tokenCount: 40, parameterCount: 1, maxNestingDepth: 2, startLine: 0, endLine: 0 },
-        { name: 'arrowFunction', longName: 'arrowFunction', cyclomaticComplexity: 2, nloc: 3, tokenCount: 15, parameterCount: 1, maxNestingDepth: 1, startLine: 0, endLine: 0 }
+        { name: 'simpleFunction', longName: 'simpleFunction', cyclomaticComplexity: 2, cognitiveComplexity: 0, nloc: 5, tokenCount: 20, parameterCount: 1, maxNestingDepth: 1, startLine: 0, endLine: 0 },
+        { name: 'complexFunction', longName: 'complexFunction', cyclomaticComplexity: 5, cognitiveComplexity: 0, nloc: 10, tokenCount: 40, parameterCount: 1, maxNestingDepth: 2, startLine: 0, endLine: 0 },
+        { name: 'arrowFunction', longName: 'arrowFunction', cyclomaticComplexity: 2, cognitiveComplexity: 0, nloc: 3, tokenCount: 15, parameterCount: 1, maxNestingDepth: 1, startLine: 0, endLine: 0 }
     ];
 
     const tsBoundaries = FunctionBoundaryDetector.detectBoundaries(tsCode, tsFunctions, Language.TypeScript);
@@ -143,9 +143,9 @@ def nested_function():
     return inner()`;
 
     const pythonFunctions: FunctionInfo[] = [
-        { name: 'simple_function', longName: 'simple_function', cyclomaticComplexity: 2, nloc: 4, tokenCount: 15, parameterCount: 1, maxNestingDepth: 1, startLine: 0, endLine: 0 },
-        { name: 'complex_function', longName: 'complex_function', cyclomaticComplexity: 5, nloc: 9, tokenCount: 35, parameterCount: 1, maxNestingDepth: 2, startLine: 0, endLine: 0 },
-        { name: 'nested_function', longName: 'nested_function', cyclomaticComplexity: 1, nloc: 3, tokenCount: 10, parameterCount: 0, maxNestingDepth: 1, startLine: 0, endLine: 0 }
+        { name: 'simple_function', longName: 'simple_function', cyclomaticComplexity: 2, cognitiveComplexity: 0, nloc: 4, tokenCount: 15, parameterCount: 1, maxNestingDepth: 1, startLine: 0, endLine: 0 },
+        { name: 'complex_function', longName: 'complex_function', cyclomaticComplexity: 5, cognitiveComplexity: 0, nloc: 9, tokenCount: 35, parameterCount: 1, maxNestingDepth: 2, startLine: 0, endLine: 0 },
+        { name: 'nested_function', longName: 'nested_function', cyclomaticComplexity: 1, cognitiveComplexity: 0, nloc: 3, tokenCount: 10, parameterCount: 0, maxNestingDepth: 1, startLine: 0, endLine: 0 }
     ];
 
     const pythonBoundaries = FunctionBoundaryDetector.detectBoundaries(pythonCode, pythonFunctions, Language.Python);
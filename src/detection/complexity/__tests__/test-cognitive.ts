@@ -0,0 +1,151 @@
+/**
+ * Test for CognitiveComplexityCalculator
+ *
+ * Checks that nesting is penalised so code with the same CCN can score
+ * differently, and that the Sonar-style increments are applied.
+ *
+ * Run with: npx ts-node src/detection/complexity/__tests__/test-cognitive.ts
+ */
+
+import { ComplexityAnalyzer } from '../analyzer';
+import { createChecks } from '../../../__tests__/checks';
+
+interface CognitiveCase {
+    name: string;
+    filename: string;
+    code: string;
+    expected: Record<string, number>;
+    /** Expected increments per function, as "<line> <construct> +<increment>" (1-indexed lines) */
+    breakdown?: Record<string, string[]>;
+}
+
+const cases: CognitiveCase[] = [
+    {
+        // Three decision points in each function; nesting should separate them
+        name: 'Go: flat switch vs nested loops',
+        filename: 'handlers.go',
+        code: `package main
+
+func flatSwitch(op string) int {
+    switch op {
+    case "add":
+        return 1
+    case "sub":
+        return 2
+    case "mul":
+        return 3
+    }
+    return 0
+}
+
+func nestedLoops(items []int, capacity int) int {
+    best := 0
+    for _, item := range items {
+        for w := capacity; w >= item; w-- {
+            if w > best {
+                best = w
+            }
+        }
+    }
+    return best
+}`,
+        expected: { flatSwitch: 1, nestedLoops: 6 },
+        breakdown: { nestedLoops: ['17 for +1', '18 for +2', '19 if +3'] }
+    },
+    {
+        name: 'Go: else-if chain and boolean sequences',
+        filename: 'chain.go',
+        code: `package main
+
+func classify(a, b, c bool) int {
+    if a && b && c {
+        return 1
+    } else if a || b {
+        return 2
+    } else {
+        return 3
+    }
+}`,
+        // if(1) + && chain(1) + else if(1) + || chain(1) + else(1)
+        expected: { classify: 5 },
+        breakdown: { classify: ['4 if +1', '4 && +1', '6 else if +1', '6 || +1', '8 else +1'] }
+    },
+    {
+        name: 'TypeScript: recursion, ternary and optional chaining',
+        filename: 'tree.ts',
+        code: `
+function depth(node: TreeNode | undefined): number {
+    if (!node) {
+        return 0;
+    }
+    const left = node.left?.value ?? 0;
+    return left > 0 ? 1 + depth(node.left) : depth(node.right);
+}`,
+        // if(1) + ternary(1) + two recursive calls(2); ?. and ?? are ignored
+        expected: { depth: 4 }
+    },
+    {
+        name: 'TypeScript: do-while counted once',
+        filename: 'loop.ts',
+        code: `
+function drain(queue: number[]): number {
+    let total = 0;
+    do {
+        if (queue.length > 0) {
+            total += queue.pop()!;
+        }
+    } while (queue.length > 0);
+    return total;
+}`,
+        // do(1) + nested if(2)
+        expected: { drain: 3 }
+    }
+];
+
+function testCognitiveComplexity(): boolean {
+    console.log('\n=== Testing CognitiveComplexityCalculator ===\n');
+
+    const { ok, finish } = createChecks();
+
+    for (const testCase of cases) {
+        console.log(`Test: ${testCase.name}`);
+        const analyzer = ComplexityAnalyzer.forFile(testCase.filename);
+        const result = analyzer.analyze(testCase.code);
+
+        for (const [functionName, expected] of Object.entries(testCase.expected)) {
+            const fn = result.functions.find(f => f.name === functionName);
+            if (!fn) {
+                ok(`${functionName}: not detected`, false);
+                continue;
+            }
+
+            const matches = fn.cognitiveComplexity === expected;
+            ok(matches
+                ? `${functionName}: cognitive ${fn.cognitiveComplexity} (CCN ${fn.cyclomaticComplexity})`
+                : `${functionName}: expected cognitive ${expected}, got ${fn.cognitiveComplexity}`,
+                matches);
+        }
+
+        for (const [functionName, expected] of Object.entries(testCase.breakdown || {})) {
+            const fn = result.functions.find(f => f.name === functionName);
+            const actual = (fn?.cognitiveIncrements || []).map(step => `${step.line + 1} ${step.construct} +${step.increment}`);
+            const matches = JSON.stringify(actual) === JSON.stringify(expected);
+            ok(matches
+                ? `${functionName} breakdown: ${actual.join(', ')}`
+                : `${functionName} breakdown: expected ${expected.join(', ')}, got ${actual.join(', ')}`,
+                matches);
+        }
+
+        // The CCN breakdown must add up to the CCN it explains
+        for (const fn of result.functions.filter(f => f.name !== '*global*')) {
+            const points = (fn.decisionPoints || []).length;
+            ok(`${fn.name} decision points: 1 + ${points} = CCN ${fn.cyclomaticComplexity}`,
+                points + 1 === fn.cyclomaticComplexity);
+        }
+    }
+
+    return finish();
+}
+
+// Run the test
+process.exit(testCognitiveComplexity() ? 0 : 1);
@@ -0,0 +1,45 @@
+/**
+ * Test for Go generics (type parameters on functions, methods and types)
+ *
+ * Run with: npx ts-node src/detection/complexity/__tests__/test-generics.ts
+ */
+
+import * as fs from 'fs';
+import * as path from 'path';
+import { ComplexityAnalyzer } from '../analyzer';
+import { createChecks } from '../../../__tests__/checks';
+
+function testGoGenerics(): boolean {
+    console.log('\n=== Testing Go Generics ===\n');
+    const { check, finish } = createChecks();
+
+    const goFilePath = path.join(__dirname, 'test-go-generics.go');
+    const functions = ComplexityAnalyzer.forFile(goFilePath).analyze(fs.readFileSync(goFilePath, 'utf-8')).functions
+        .filter(f => f.name !== '*global*');
+    const fn = (name: string) => functions.find(f => f.name === name)!;
+
+    // Test 1: every function is found with its own lines
+    console.log('Test 1: Function boundaries');
+    check('functions', functions.map(f => f.name).sort(), ['Keys', 'Map', 'Push', 'Sum', 'identity', 'mapStrings']);
+    check('Map lines', [fn('Map').startLine + 1, fn('Map').endLine + 1], [14, 22]);
+    check('Push lines (generic receiver)', [fn('Push').startLine + 1, fn('Push').endLine + 1], [36, 41]);
+    check('Keys lines (generic constraint)', [fn('Keys').startLine + 1, fn('Keys').endLine + 1], [44, 50]);
+
+    // Test 2: type parameters are counted apart from parameters
+    console.log('\nTest 2: Type parameters');
+    check('Map parameters', [fn('Map').parameterCount, fn('Map').typeParameterCount], [2, 2]);
+    check('Keys parameters', [fn('Keys').parameterCount, fn('Keys').typeParameterCount], [1, 3]);
+    check('Push has no type parameters of its own', fn('Push').typeParameterCount, undefined);
+    check('Map signature', fn('Map').longName.startsWith('Map[T , U any]'), true);
+
+    // Test 3: type parameters don't change scoring
+    console.log('\nTest 3: Scoring');
+    check('Map CCN matches non-generic twin', fn('Map').cyclomaticComplexity, fn('mapStrings').cyclomaticComplexity);
+    check('Map cognitive matches non-generic twin', fn('Map').cognitiveComplexity, fn('mapStrings').cognitiveComplexity);
+    check('Sum cognitive (instantiation is not a branch)', fn('Sum').cognitiveComplexity, 1);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testGoGenerics() ? 0 : 1);
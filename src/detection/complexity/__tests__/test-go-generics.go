@@ -0,0 +1,63 @@
+package coll
+
+// Number is a constraint interface; its union terms are not branches.
+type Number interface {
+	~int | ~int64 | ~float64
+}
+
+// Stack is a generic type; its body is not a function.
+type Stack[T any] struct {
+	items []T
+}
+
+// Map is a generic function with two type parameters.
+func Map[T, U any](xs []T, f func(T) U) []U {
+	out := make([]U, 0, len(xs))
+	for _, x := range xs {
+		if f != nil {
+			out = append(out, f(x))
+		}
+	}
+	return out
+}
+
+// mapStrings is Map without type parameters, and should score the same.
+func mapStrings(xs []string, f func(string) string) []string {
+	out := make([]string, 0, len(xs))
+	for _, x := range xs {
+		if f != nil {
+			out = append(out, f(x))
+		}
+	}
+	return out
+}
+
+// Push is a method on a generic type.
+func (s *Stack[T]) Push(v T) {
+	if len(s.items) >= 1024 {
+		return
+	}
+	s.items = append(s.items, v)
+}
+
+// Keys has a constraint that is itself generic.
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Sum instantiates Map and identity explicitly.
+func Sum[N Number](xs []N) N {
+	var total N
+	for _, x := range Map[N, N](xs, identity[N]) {
+		total += x
+	}
+	return total
+}
+
+func identity[T any](v T) T {
+	return v
+}
@@ -0,0 +1,82 @@
+/**
+ * Test for HalsteadCalculator and the maintainability index
+ *
+ * Run with: npx ts-node src/detection/complexity/__tests__/test-halstead.ts
+ */
+
+import { ComplexityAnalyzer } from '../analyzer';
+import { HalsteadCalculator } from '../halstead';
+import { createChecks } from '../../../__tests__/checks';
+
+function testHalstead(): boolean {
+    console.log('\n=== Testing HalsteadCalculator ===\n');
+    const { ok, finish } = createChecks();
+    const check = (label: string, actual: number, expected: number) => {
+        const passed = Math.abs(actual - expected) < 0.01;
+        ok(`${label}: ${Number.isInteger(actual) ? actual : actual.toFixed(2)}${passed ? '' : ` (expected ${expected})`}`, passed);
+    };
+
+    // Test 1: hand-counted Go function
+    // Operators: ( , { return +        -> n1 = 5, N1 = 5
+    // Operands:  a b int int a b       -> n2 = 3, N2 = 6
+    console.log('Test 1: Go add(a, b int) int');
+    const goCode = `package main
+
+func add(a, b int) int {
+    return a + b
+}`;
+    const result = ComplexityAnalyzer.forFile('math.go').analyze(goCode);
+    const add = result.functions.find(f => f.name === 'add');
+
+    if (!add || !add.halstead) {
+        console.log('  ✗ add: not detected');
+        return false;
+    }
+
+    check('distinct operators (n1)', add.halstead.distinctOperators, 5);
+    check('distinct operands (n2)', add.halstead.distinctOperands, 3);
+    check('total operators (N1)', add.halstead.totalOperators, 5);
+    check('total operands (N2)', add.halstead.totalOperands, 6);
+    check('volume', add.halstead.volume, 33);
+    check('difficulty', add.halstead.difficulty, 5);
+    check('effort', add.halstead.effort, 165);
+    check('nloc', add.nloc, 3);
+    check('maintainability index', add.maintainabilityIndex ?? -1, 79);
+
+    // Test 2: MI decreases as code grows and branches
+    console.log('\nTest 2: Maintainability index ordering');
+    const small = HalsteadCalculator.maintainabilityIndex(33, 1, 3);
+    const large = HalsteadCalculator.maintainabilityIndex(2000, 20, 80);
+    ok(`small (${small}) > large (${large})`, small > large);
+
+    check('empty code clamps to 100', HalsteadCalculator.maintainabilityIndex(0, 0, 0), 100);
+
+    // Test 3: package aggregate, weighted by NLOC
+    console.log('\nTest 3: Package maintainability index');
+    const withIndex = (nloc: number, maintainabilityIndex?: number) => ({ ...add, nloc, maintainabilityIndex });
+    check('NLOC-weighted mean', HalsteadCalculator.aggregateMaintainabilityIndex([withIndex(1, 90), withIndex(3, 50)])!, 60);
+    check('functions without an index are skipped', HalsteadCalculator.aggregateMaintainabilityIndex([withIndex(2, 40), withIndex(5)])!, 40);
+    ok('no functions: undefined', HalsteadCalculator.aggregateMaintainabilityIndex([]) === undefined);
+
+    // Test 4: docstrings are not code, other lines still count
+    console.log('\nTest 4: Python docstring NLOC');
+    const documented = `def area(w, h):
+    """Area of a rectangle.
+
+    Both sides in metres.
+    """
+    return w * h
+`;
+    const inline = `def perimeter(w, h): """Sum of the sides."""
+    total = 2 * (w + h)
+    return total
+`;
+    const python = ComplexityAnalyzer.forFile('shapes.py');
+    check('multi-line docstring', python.analyze(documented).functions.find(f => f.name === 'area')?.nloc ?? -1, 2);
+    check('docstring on the def line', python.analyze(inline).functions.find(f => f.name === 'perimeter')?.nloc ?? -1, 3);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testHalstead() ? 0 : 1);
@@ -41,6 +41,8 @@ function testGoExample() {
 
     console.log(`${colors.bright}${colors.blue}Analysis Results:${colors.reset}`);
     console.log(`  Total CCN: ${result.totalCCN}`);
+    console.log(`  Total Cognitive: ${result.totalCognitive}`);
+    console.log(`  Maintainability Index: ${result.maintainabilityIndex}`);
     console.log(`  NLOC: ${result.nloc}`);
     console.log(`  Functions detected: ${result.functions.length}\n`);
 
@@ -65,6 +67,11 @@ function testGoExample() {
         console.log(`${idx + 1}. ${colors.bright}${fn.name}${colors.reset}`);
         console.log(`   Location: Lines ${fn.startLine}-${fn.endLine}`);
         console.log(`   CCN: ${color}${fn.cyclomaticComplexity}${colors.reset} (${level})`);
+        console.log(`   Cognitive: ${fn.cognitiveComplexity}`);
+        if (fn.halstead) {
+            console.log(`   Halstead: V=${fn.halstead.volume.toFixed(1)}, D=${fn.halstead.difficulty.toFixed(1)}, E=${fn.halstead.effort.toFixed(0)}`);
+        }
+        console.log(`   Maintainability Index: ${fn.maintainabilityIndex}`);
         console.log(`   NLOC: ${fn.nloc}`);
         console.log(`   Parameters: ${fn.parameterCount}`);
         console.log(`   Signature: ${fn.longName}`);
@@ -9,12 +9,19 @@
  */
 
 import { Tokenizer } from './tokenizer';
-import { FunctionInfo, Language } from './types';
+import { FunctionInfo, HalsteadMetrics, Language } from './types';
 import { FunctionContext } from './functionContext';
 import { GoStateMachine } from './goStateMachine';
 import { TypeScriptStateMachine } from './typeScriptStateMachine';
 import { PythonStateMachine } from './pythonStateMachine';
 import { StateMachine } from './stateMachine';
+import { CognitiveComplexityCalculator } from './cognitiveComplexity';
+import { HalsteadCalculator } from './halstead';
+
+/**
+ * Python triple-quoted strings, so a multi-line docstring is a single token
+ */
+const PYTHON_STRING_PATTERN = `"""[\\s\\S]*?"""|'''[\\s\\S]*?'''`;
 
 /**
  * Language-specific complexity conditions
@@ -79,6 +86,9 @@ export interface AnalysisResult {
     /** Total cyclomatic complexity */
     totalCCN: number;
 
+    /** Total cognitive complexity */
+    totalCognitive: number;
+
     /** Lines of code (non-blank, non-comment) */
     nloc: number;
 
@@ -88,6 +98,12 @@ export interface AnalysisResult {
     /** Number of decision points found */
     decisionPoints: number;
 
+    /** Halstead metrics for the whole analyzed code */
+    halstead: HalsteadMetrics;
+
+    /** Maintainability index for the whole analyzed code (0-100) */
+    maintainabilityIndex: number;
+
     /** Functions detected (Phase 2 feature - empty for now) */
     functions: FunctionInfo[];
 }
@@ -114,6 +130,13 @@ export class ComplexityAnalyzer {
         this.filename = filename;
     }
 
+    /**
+     * Get the language this analyzer was created for
+     */
+    getLanguage(): Language {
+        return this.language;
+    }
+
     /**
      * Analyze source code and calculate complexity metrics
      *
@@ -140,28 +163,32 @@ export class ComplexityAnalyzer {
      */
     private analyzeFunctionLevel(sourceCode: string): AnalysisResult {
         // Create function context
-        const context = new FunctionContext(this.filename);
+        const context = new FunctionContext(this.filename, this.language);
 
         // Create state machine for language
         const stateMachine = this.createStateMachine(context);
 
         // Tokenize the code
-        const tokens = Tokenizer.generateTokens(sourceCode);
+        const tokens = this.tokenize(sourceCode);
 
-        // Filter to code tokens (excluding comments/whitespace but keep newlines for tracking)
+        // Filter to code tokens (excluding comments/whitespace)
         const codeTokens = Tokenizer.filterCodeTokens(tokens);
 
-        // Process each token through state machine
+        // Process code tokens through the state machine, using the
+        // whitespace/comment tokens only to track line numbers
         let lineNumber = 0;
-        for (const token of codeTokens) {
-            // Track line numbers (approximate)
-            if (token === '\n') {
-                lineNumber++;
-                context.setLine(lineNumber);
+        for (const token of tokens) {
+            if (Tokenizer.isCodeToken(token)) {
+                // Record token against the current function, then process it
+                context.addToken(token);
+                stateMachine.processToken(token);
             }
 
-            // Process token
-            stateMachine.processToken(token);
+            const newlines = this.countNewlines(token);
+            if (newlines > 0) {
+                lineNumber += newlines;
+                context.setLine(lineNumber);
+            }
         }
 
         // Finalize any unclosed functions
@@ -171,11 +198,21 @@ export class ComplexityAnalyzer {
         const totalCCN = functions.reduce((sum, fn) => sum + fn.cyclomaticComplexity, 0);
         const nloc = Tokenizer.countNLOC(sourceCode);
 
+        // Snippets without a function declaration still get a cognitive score
+        const totalCognitive = functions.length > 0
+            ? functions.reduce((sum, fn) => sum + fn.cognitiveComplexity, 0)
+            : new CognitiveComplexityCalculator(this.language).calculate(codeTokens);
+
+        const halstead = new HalsteadCalculator(this.language).calculate(codeTokens);
+
         return {
             totalCCN: totalCCN || 1,  // At least 1 if no functions detected
+            totalCognitive,
             nloc,
             tokenCount: codeTokens.length,
             decisionPoints: totalCCN - functions.length,  // Approximate
+            halstead,
+            maintainabilityIndex: HalsteadCalculator.maintainabilityIndex(halstead.volume, totalCCN || 1, nloc),
             functions
         };
     }
@@ -185,7 +222,7 @@ export class ComplexityAnalyzer {
      */
     private analyzeAggregate(sourceCode: string): AnalysisResult {
         // Tokenize the code
-        const tokens = Tokenizer.generateTokens(sourceCode);
+        const tokens = this.tokenize(sourceCode);
 
         // Filter to code tokens only (no comments/whitespace)
         const codeTokens = Tokenizer.filterCodeTokens(tokens);
@@ -205,11 +242,17 @@ export class ComplexityAnalyzer {
         // Calculate NLOC
         const nloc = Tokenizer.countNLOC(sourceCode);
 
+        const totalCognitive = new CognitiveComplexityCalculator(this.language).calculate(codeTokens);
+        const halstead = new HalsteadCalculator(this.language).calculate(codeTokens);
+
         return {
             totalCCN,
+            totalCognitive,
             nloc,
             tokenCount: codeTokens.length,
             decisionPoints,
+            halstead,
+            maintainabilityIndex: HalsteadCalculator.maintainabilityIndex(halstead.volume, totalCCN, nloc),
             functions: []
         };
     }
@@ -230,6 +273,28 @@ export class ComplexityAnalyzer {
         }
     }
 
+    /**
+     * Tokenize with the language's own string syntax
+     */
+    private tokenize(sourceCode: string): string[] {
+        return Tokenizer.generateTokens(sourceCode, {
+            additionalPatterns: this.language === Language.Python ? PYTHON_STRING_PATTERN : undefined
+        });
+    }
+
+    /**
+     * Count line breaks in a token (newline, line continuation or multi-line comment)
+     */
+    private countNewlines(token: string): number {
+        let count = 0;
+        for (const char of token) {
+            if (char === '\n') {
+                count++;
+            }
+        }
+        return count;
+    }
+
     /**
      * Check if a token is a decision point that increases CCN
      */
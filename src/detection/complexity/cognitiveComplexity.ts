@@ -0,0 +1,235 @@
+/**
+ * Cognitive Complexity Calculator
+ * Based on SonarSource's Cognitive Complexity specification
+ *
+ * Unlike CCN, cognitive complexity penalises nesting:
+ * - Structural keywords (if, loops, switch, catch) cost 1 + current nesting
+ * - else / else if / elif cost a flat 1 (no nesting penalty)
+ * - Each run of like boolean operators costs 1 (a && b && c = 1, a && b || c = 2)
+ * - Each recursive call costs 1
+ *
+ * Works on the same token stream as the state machines, so nesting is
+ * tracked with braces. Python blocks are indentation-based and indentation
+ * is dropped by the tokenizer, so Python only receives flat increments.
+ */
+
+import { CognitiveIncrement, Language } from './types';
+
+/**
+ * Language-specific cognitive complexity keywords
+ */
+interface CognitiveKeywords {
+    /** Keywords that add 1 + nesting and open a nested block */
+    structural: Set<string>;
+
+    /** Keywords that add a flat 1 and open a nested block (else, elif) */
+    hybrid: Set<string>;
+
+    /** Keywords that open a nested block without any increment of their own */
+    nestingOnly: Set<string>;
+
+    /** Boolean operators that form short-circuit chains */
+    logicalOperators: Set<string>;
+
+    /** Whether the language uses ?: as a ternary operator */
+    hasTernary: boolean;
+}
+
+const COGNITIVE_KEYWORDS: Record<Language, CognitiveKeywords> = {
+    [Language.TypeScript]: {
+        structural: new Set(['if', 'for', 'while', 'do', 'switch', 'catch']),
+        hybrid: new Set(['else']),
+        nestingOnly: new Set(['function', '=>']),
+        logicalOperators: new Set(['&&', '||']),
+        hasTernary: true
+    },
+    [Language.JavaScript]: {
+        structural: new Set(['if', 'for', 'while', 'do', 'switch', 'catch']),
+        hybrid: new Set(['else']),
+        nestingOnly: new Set(['function', '=>']),
+        logicalOperators: new Set(['&&', '||']),
+        hasTernary: true
+    },
+    [Language.Go]: {
+        structural: new Set(['if', 'for', 'switch', 'select']),
+        hybrid: new Set(['else']),
+        nestingOnly: new Set(['func']),  // closures
+        logicalOperators: new Set(['&&', '||']),
+        hasTernary: false
+    },
+    [Language.Python]: {
+        structural: new Set(['if', 'for', 'while', 'except']),
+        hybrid: new Set(['elif', 'else']),
+        nestingOnly: new Set(['lambda']),
+        logicalOperators: new Set(['and', 'or']),
+        hasTernary: false
+    }
+};
+
+/**
+ * An increment located by token index, before lines are known
+ */
+type TokenIncrement = Omit<CognitiveIncrement, 'line'> & { index: number };
+
+/**
+ * Tokens that end a boolean operator chain
+ */
+const SEQUENCE_BREAKERS = new Set(['(', ')', '{', '}', '[', ']', ',', ';', ':', '=', ':=']);
+
+export class CognitiveComplexityCalculator {
+    private keywords: CognitiveKeywords;
+    private language: Language;
+
+    constructor(language: Language) {
+        this.language = language;
+        this.keywords = COGNITIVE_KEYWORDS[language];
+    }
+
+    /**
+     * Calculate cognitive complexity for a token sequence
+     *
+     * @param tokens - Code tokens (comments/whitespace already filtered)
+     * @param functionName - Name of the enclosing function, used to detect recursion
+     * @returns Cognitive complexity (0 for straight-line code)
+     */
+    calculate(tokens: string[], functionName: string = ''): number {
+        return this.walk(tokens, functionName).complexity;
+    }
+
+    /**
+     * List every construct that adds to cognitive complexity
+     * The increments sum to calculate() for the same tokens
+     *
+     * @param tokens - Code tokens (comments/whitespace already filtered)
+     * @param lines - Line of each token, parallel to tokens
+     * @param functionName - Name of the enclosing function, used to detect recursion
+     */
+    explain(tokens: string[], lines: number[], functionName: string = ''): CognitiveIncrement[] {
+        return this.walk(tokens, functionName).increments.map(({ index, ...increment }) => ({
+            ...increment,
+            line: lines[index] ?? 0
+        }));
+    }
+
+    /**
+     * Deepest nesting of control structures and closures in a token sequence
+     * Always 0 for Python (see module comment)
+     *
+     * @param tokens - Code tokens (comments/whitespace already filtered)
+     */
+    maxNesting(tokens: string[]): number {
+        return this.walk(tokens, '').maxNesting;
+    }
+
+    private walk(tokens: string[], functionName: string): { complexity: number; maxNesting: number; increments: TokenIncrement[] } {
+        let complexity = 0;
+        const increments: TokenIncrement[] = [];
+        const add = (index: number, construct: string, increment: number, nestingPenalty: number) => {
+            complexity += increment;
+            increments.push({ index, construct, increment, nesting: nestingPenalty });
+        };
+
+        // Each entry records whether that brace block adds a nesting level
+        const blockStack: boolean[] = [];
+        let nesting = 0;
+        let maxNesting = 0;
+
+        // Set when a keyword has been seen whose body has not opened yet
+        let pendingBlock = false;
+        let pendingParenDepth = 0;
+        let parenDepth = 0;
+
+        // Track do { } while (...) so the trailing while is not counted
+        let lastClosedWasDo = false;
+        let doBlockPending = false;
+        const doBlocks: boolean[] = [];
+
+        let lastLogicalOperator: string | null = null;
+
+        for (let i = 0; i < tokens.length; i++) {
+            const token = tokens[i];
+            const prev = i > 0 ? tokens[i - 1] : '';
+            const next = i + 1 < tokens.length ? tokens[i + 1] : '';
+
+            if (token === '(') {
+                parenDepth++;
+            } else if (token === ')') {
+                parenDepth = Math.max(0, parenDepth - 1);
+            }
+
+            if (token === '{') {
+                const opensNested = pendingBlock && parenDepth === pendingParenDepth;
+                blockStack.push(opensNested);
+                doBlocks.push(opensNested && doBlockPending);
+                if (opensNested) {
+                    nesting++;
+                    maxNesting = Math.max(maxNesting, nesting);
+                    pendingBlock = false;
+                    doBlockPending = false;
+                }
+                lastClosedWasDo = false;
+            } else if (token === '}') {
+                if (blockStack.pop()) {
+                    nesting = Math.max(0, nesting - 1);
+                }
+                lastClosedWasDo = doBlocks.pop() === true;
+            } else if (token === ';' && this.language !== Language.Go && parenDepth === pendingParenDepth) {
+                // Brace-less body (if (x) return;) - nothing to nest
+                pendingBlock = false;
+                doBlockPending = false;
+            }
+
+            if (this.keywords.structural.has(token)) {
+                if (token === 'while' && prev === '}' && lastClosedWasDo) {
+                    // Tail of do { } while (...) - already counted at 'do'
+                    lastClosedWasDo = false;
+                } else if (token === 'if' && prev === 'else') {
+                    // else if - the else already paid its flat increment
+                } else {
+                    add(i, token, 1 + nesting, nesting);
+                    pendingBlock = true;
+                    pendingParenDepth = parenDepth;
+                    doBlockPending = token === 'do';
+                }
+            } else if (this.keywords.hybrid.has(token)) {
+                add(i, next === 'if' ? 'else if' : token, 1, 0);
+                pendingBlock = true;
+                pendingParenDepth = parenDepth;
+            } else if (this.keywords.nestingOnly.has(token)) {
+                pendingBlock = true;
+                pendingParenDepth = parenDepth;
+            } else if (this.keywords.hasTernary && this.isTernary(token, prev, next)) {
+                add(i, '?:', 1 + nesting, nesting);
+            }
+
+            // Boolean operator chains
+            if (this.keywords.logicalOperators.has(token)) {
+                if (token !== lastLogicalOperator) {
+                    add(i, token, 1, 0);
+                }
+                lastLogicalOperator = token;
+            } else if (SEQUENCE_BREAKERS.has(token) || this.keywords.structural.has(token)) {
+                lastLogicalOperator = null;
+            }
+
+            // Recursion
+            if (functionName && token === functionName && next === '(') {
+                add(i, 'recursion', 1, 0);
+            }
+        }
+
+        return { complexity, maxNesting, increments };
+    }
+
+    /**
+     * Distinguish the ternary '?' from optional chaining, nullish
+     * coalescing and optional parameters/properties
+     */
+    private isTernary(token: string, prev: string, next: string): boolean {
+        if (token !== '?') {
+            return false;
+        }
+        return next !== '.' && next !== ':' && next !== '?' && prev !== '?' &&
+            next !== ')' && next !== ',' && next !== '=';
+    }
+}
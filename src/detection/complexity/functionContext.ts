@@ -8,7 +8,20 @@
  * - Functions complete in LIFO order
  */
 
-import { FunctionInfo } from './types';
+import { FunctionInfo, Language } from './types';
+import { CognitiveComplexityCalculator } from './cognitiveComplexity';
+import { HalsteadCalculator } from './halstead';
+
+/**
+ * Tokens recorded for a function while it is being parsed
+ */
+interface TokenTrace {
+    tokens: string[];
+    /** Line of each token, for cognitive complexity breakdowns */
+    lines: number[];
+    /** Last line a token was recorded on (for NLOC) */
+    lastLine: number;
+}
 
 export class FunctionContext {
     private currentFunction: FunctionInfo;
@@ -16,8 +29,22 @@ export class FunctionContext {
     private globalPseudoFunction: FunctionInfo;
     public currentLine: number;
     private completedFunctions: FunctionInfo[];
+    private currentTrace: TokenTrace;
+    private stackedTraces: TokenTrace[];
+    private cognitive?: CognitiveComplexityCalculator;
+    private halstead?: HalsteadCalculator;
 
-    constructor(filename: string) {
+    /** Bracket depth inside the parameter list, so commas in generics and defaults are skipped */
+    private parameterDepth: number = 0;
+
+    /** Set after a top-level comma until the next parameter starts */
+    private awaitingParameter: boolean = false;
+
+    /**
+     * @param filename - File being analyzed (used in the global pseudo-function name)
+     * @param language - When given, per-function cognitive and Halstead metrics are computed
+     */
+    constructor(filename: string, language?: Language) {
         // Global pseudo-function to catch code outside functions
         this.globalPseudoFunction = {
             name: '*global*',
@@ -25,6 +52,7 @@ export class FunctionContext {
             startLine: 0,
             endLine: 0,
             cyclomaticComplexity: 1,
+            cognitiveComplexity: 0,
             nloc: 0,
             tokenCount: 0,
             parameterCount: 0,
@@ -35,6 +63,13 @@ export class FunctionContext {
         this.stackedFunctions = [];
         this.currentLine = 0;
         this.completedFunctions = [];
+        this.currentTrace = { tokens: [], lines: [], lastLine: -1 };
+        this.stackedTraces = [];
+
+        if (language) {
+            this.cognitive = new CognitiveComplexityCalculator(language);
+            this.halstead = new HalsteadCalculator(language);
+        }
     }
 
     /**
@@ -44,6 +79,10 @@ export class FunctionContext {
     public pushNewFunction(name: string, parameterCount: number = 0): void {
         // Save current function to stack
         this.stackedFunctions.push(this.currentFunction);
+        this.stackedTraces.push(this.currentTrace);
+        this.currentTrace = { tokens: [], lines: [], lastLine: -1 };
+        this.parameterDepth = 0;
+        this.awaitingParameter = false;
 
         const functionName = name || '(anonymous)';
 
@@ -54,6 +93,7 @@ export class FunctionContext {
             startLine: this.currentLine,
             endLine: this.currentLine,
             cyclomaticComplexity: 1,  // Base CCN
+            cognitiveComplexity: 0,
             nloc: 0,
             tokenCount: 0,
             parameterCount,
@@ -69,6 +109,18 @@ export class FunctionContext {
     public endOfFunction(): FunctionInfo {
         const completed = { ...this.currentFunction };
         completed.endLine = this.currentLine;
+        if (this.cognitive) {
+            completed.cognitiveIncrements = this.cognitive.explain(this.currentTrace.tokens, this.currentTrace.lines, completed.name);
+            completed.cognitiveComplexity = completed.cognitiveIncrements.reduce((total, step) => total + step.increment, 0);
+        }
+        if (this.halstead) {
+            completed.halstead = this.halstead.calculate(this.currentTrace.tokens);
+            completed.maintainabilityIndex = HalsteadCalculator.maintainabilityIndex(
+                completed.halstead.volume,
+                completed.cyclomaticComplexity,
+                completed.nloc
+            );
+        }
 
         // Store completed function
         // Don't store global pseudo-function unless it has actual code
@@ -79,9 +131,11 @@ export class FunctionContext {
         // Pop parent function from stack
         if (this.stackedFunctions.length > 0) {
             this.currentFunction = this.stackedFunctions.pop()!;
+            this.currentTrace = this.stackedTraces.pop()!;
         } else {
             // Reset to global if no parent
             this.currentFunction = { ...this.globalPseudoFunction };
+            this.currentTrace = { tokens: [], lines: [], lastLine: -1 };
         }
 
         return completed;
@@ -90,9 +144,19 @@ export class FunctionContext {
     /**
      * Add condition/decision point to current function's CCN
      * Called when state machine encounters if/for/while/&&/||/case/etc
+     *
+     * @param construct - Condition token, recorded as a decision point for CCN breakdowns
      */
-    public addCondition(increment: number = 1): void {
+    public addCondition(increment: number = 1, construct?: string): void {
         this.currentFunction.cyclomaticComplexity += increment;
+
+        // The global pseudo-function is copied on reset, so it keeps no list to share
+        if (construct && this.currentFunction.name !== '*global*') {
+            const points = this.currentFunction.decisionPoints || (this.currentFunction.decisionPoints = []);
+            for (let i = 0; i < increment; i++) {
+                points.push({ line: this.currentLine, construct });
+            }
+        }
     }
 
     /**
@@ -103,10 +167,36 @@ export class FunctionContext {
     }
 
     /**
-     * Increment token count
+     * Record a token for the current function
+     * Increments token count, keeps the token for cognitive/Halstead metrics,
+     * and counts the current line towards the function's NLOC
      */
-    public addToken(): void {
+    public addToken(token: string): void {
         this.currentFunction.tokenCount++;
+        this.currentTrace.tokens.push(token);
+        this.currentTrace.lines.push(this.currentLine);
+
+        // The global pseudo-function is only kept when it has NLOC, so code
+        // outside functions must not count towards it
+        if (this.currentTrace.lastLine !== this.currentLine && this.currentFunction.name !== '*global*') {
+            this.currentFunction.nloc++;
+            this.currentTrace.lastLine = this.currentLine;
+        }
+    }
+
+    /**
+     * Stop the token just recorded counting towards NLOC (Python docstrings)
+     * Its line still counts when an earlier token of the function is on it
+     */
+    public excludeTokenFromNloc(): void {
+        const lines = this.currentTrace.lines;
+        const line = lines[lines.length - 1];
+        const previous = lines.length > 1 ? lines[lines.length - 2] : -1;
+
+        if (line !== previous && this.currentTrace.lastLine === line && this.currentFunction.name !== '*global*') {
+            this.currentFunction.nloc--;
+            this.currentTrace.lastLine = previous;
+        }
     }
 
     /**
@@ -188,18 +278,45 @@ export class FunctionContext {
         this.currentFunction.longName += text;
     }
 
+    /**
+     * Set the number of type parameters of the current function
+     */
+    public setTypeParameterCount(count: number): void {
+        this.currentFunction.typeParameterCount = count;
+    }
+
     /**
      * Add parameter to function (updates parameter count and long name)
+     *
+     * @param angleBrackets - Treat < and > as brackets, for TypeScript generics;
+     * Go has channel directions (<-chan, chan<-) where they don't pair up
      */
-    public addParameter(token: string): void {
+    public addParameter(token: string, angleBrackets: boolean = false): void {
         this.addToLongFunctionName(' ' + token);
 
-        // Count parameters by tracking commas
-        if (token === ',') {
-            // Comma separates parameters
-        } else if (this.currentFunction.parameterCount === 0 && token.trim()) {
+        if (!token.trim()) {
+            return;
+        }
+
+        if (token === '(' || token === '[' || token === '{' || (angleBrackets && token === '<')) {
+            this.parameterDepth++;
+        } else if (token === ')' || token === ']' || token === '}' || (angleBrackets && token === '>')) {
+            this.parameterDepth = Math.max(0, this.parameterDepth - 1);
+        }
+
+        // Count parameters by tracking top-level commas
+        if (token === ',' && this.parameterDepth === 0) {
+            // Counted when the next parameter starts, so a trailing comma adds nothing
+            this.awaitingParameter = true;
+            return;
+        }
+
+        if (this.currentFunction.parameterCount === 0) {
             // First parameter
             this.currentFunction.parameterCount = 1;
+        } else if (this.awaitingParameter) {
+            this.currentFunction.parameterCount++;
         }
+        this.awaitingParameter = false;
     }
 }
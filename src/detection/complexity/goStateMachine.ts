@@ -5,6 +5,7 @@
  * - Detects Go function declarations
  * - Handles member functions (methods with receivers)
  * - Tracks function parameters and signatures
+ * - Reads type parameter lists [T any] (Go 1.18+) on functions and types
  * - Manages nested structures (structs, interfaces, function bodies)
  */
 
@@ -13,6 +14,7 @@ import { FunctionContext } from './functionContext';
 
 export class GoStateMachine extends StateMachine {
     private readonly FUNC_KEYWORD = 'func';
+    private typeParameterCount = 0;
 
     constructor(context: FunctionContext) {
         super(context);
@@ -59,7 +61,10 @@ export class GoStateMachine extends StateMachine {
      * After type name - check if it's struct or interface
      */
     private afterTypeName(token: string): void {
-        if (token === 'struct') {
+        if (token === '[') {
+            // Generic type: type Stack[T any] struct { ... }
+            this.next(this.typeParameterList.bind(this), token);
+        } else if (token === 'struct') {
             this.state = this.structDefinition.bind(this);
         } else if (token === 'interface') {
             this.state = this.interfaceDefinition.bind(this);
@@ -68,6 +73,20 @@ export class GoStateMachine extends StateMachine {
         }
     }
 
+    /**
+     * Inside a generic type's parameter list - skipped, then back to the type
+     */
+    private typeParameterList(token: string): void {
+        if (token === '[') {
+            this.bracketCount++;
+        } else if (token === ']') {
+            this.bracketCount--;
+            if (this.bracketCount === 0) {
+                this.state = this.afterTypeName.bind(this);
+            }
+        }
+    }
+
     /**
      * Inside struct definition - read until closing brace
      */
@@ -136,14 +155,15 @@ export class GoStateMachine extends StateMachine {
     }
 
     /**
-     * Expecting function declaration (parameters) or generics
+     * Expecting function declaration (parameters) or type parameters
      */
     private expectFunctionDeclaration(token: string): void {
         if (token === '(') {
             this.next(this.functionDeclaration.bind(this), token);
-        } else if (token === '<') {
-            // Generic type parameters (Go 1.18+)
-            this.next(this.generics.bind(this), token);
+        } else if (token === '[') {
+            // Type parameters (Go 1.18+): func Map[T, U any](...)
+            this.typeParameterCount = 0;
+            this.next(this.typeParameters.bind(this), token);
         } else {
             // Not a function declaration, return to global
             this.state = this.stateGlobal.bind(this);
@@ -151,19 +171,31 @@ export class GoStateMachine extends StateMachine {
     }
 
     /**
-     * Inside generic type parameters <T, U>
+     * Inside type parameters [K comparable, V any]
+     * Counted apart from parameters, since constraints are not arguments
      */
-    private generics(token: string): void {
-        if (this.bracketCount === 0 && token === '<') {
-            this.bracketCount = 1;
-        } else if (token === '<') {
+    private typeParameters(token: string): void {
+        if (token === '[') {
             this.bracketCount++;
-        } else if (token === '>') {
+            if (this.bracketCount === 1) {
+                return;
+            }
+        } else if (token === ']') {
             this.bracketCount--;
             if (this.bracketCount === 0) {
+                this.context.addToLongFunctionName('[' + this.collectedTokens.join(' ') + ']');
+                this.context.setTypeParameterCount(this.typeParameterCount);
+                this.collectedTokens = [];
                 this.state = this.expectFunctionDeclaration.bind(this);
+                return;
             }
+        } else if (token === ',' && this.bracketCount === 1) {
+            this.typeParameterCount++;
+        } else if (this.typeParameterCount === 0) {
+            this.typeParameterCount = 1;
         }
+
+        this.collectedTokens.push(token);
     }
 
     /**
@@ -197,20 +229,19 @@ export class GoStateMachine extends StateMachine {
         if (this.bracketCount === 0 && token === '(') {
             this.bracketCount = 1;
             return;
+        } else if (token === ')' && this.bracketCount === 1) {
+            this.bracketCount = 0;
+            this.state = this.expectFunctionImpl.bind(this);
+            return;
         } else if (token === '(') {
             this.bracketCount++;
         } else if (token === ')') {
             this.bracketCount--;
-            if (this.bracketCount === 0) {
-                this.state = this.expectFunctionImpl.bind(this);
-                return;
-            }
         }
 
-        // Track parameters
-        if (token !== '(' && token !== ')') {
-            this.context.addParameter(token);
-        }
+        // Nested parentheses (function-typed parameters) go through too, so
+        // the commas inside them aren't counted as parameter separators
+        this.context.addParameter(token);
     }
 
     /**
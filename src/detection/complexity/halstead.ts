@@ -0,0 +1,178 @@
+/**
+ * Halstead Metrics and Maintainability Index
+ *
+ * Halstead treats a program as a sequence of operators (keywords,
+ * punctuation, arithmetic) and operands (identifiers, literals):
+ * - n1 / n2: distinct operators / operands
+ * - N1 / N2: total operators / operands
+ * - Volume     V = N * log2(n)
+ * - Difficulty D = (n1 / 2) * (N2 / n2)
+ * - Effort     E = D * V
+ *
+ * The maintainability index uses the Visual Studio formulation,
+ * normalised to 0-100:
+ *   MI = max(0, (171 - 5.2 ln V - 0.23 CCN - 16.2 ln LOC) * 100 / 171)
+ */
+
+import { FunctionInfo, HalsteadMetrics, Language } from './types';
+
+/**
+ * Reserved words per language - counted as operators, not operands.
+ * Literal-like keywords (true, nil, None) are left out so they count as operands.
+ */
+const KEYWORDS: Record<Language, Set<string>> = {
+    [Language.TypeScript]: new Set([
+        'break', 'case', 'catch', 'class', 'const', 'continue', 'debugger', 'default',
+        'delete', 'do', 'else', 'export', 'extends', 'finally', 'for', 'function', 'if',
+        'import', 'in', 'instanceof', 'let', 'new', 'of', 'return', 'super', 'switch',
+        'this', 'throw', 'try', 'typeof', 'var', 'void', 'while', 'with', 'yield',
+        'async', 'await', 'static', 'get', 'set', 'as', 'implements', 'interface',
+        'private', 'protected', 'public', 'readonly', 'type', 'enum', 'declare', 'keyof'
+    ]),
+    [Language.JavaScript]: new Set([
+        'break', 'case', 'catch', 'class', 'const', 'continue', 'debugger', 'default',
+        'delete', 'do', 'else', 'export', 'extends', 'finally', 'for', 'function', 'if',
+        'import', 'in', 'instanceof', 'let', 'new', 'of', 'return', 'super', 'switch',
+        'this', 'throw', 'try', 'typeof', 'var', 'void', 'while', 'with', 'yield',
+        'async', 'await', 'static', 'get', 'set'
+    ]),
+    [Language.Go]: new Set([
+        'break', 'case', 'chan', 'const', 'continue', 'default', 'defer', 'else',
+        'fallthrough', 'for', 'func', 'go', 'goto', 'if', 'import', 'interface', 'map',
+        'package', 'range', 'return', 'select', 'struct', 'switch', 'type', 'var'
+    ]),
+    [Language.Python]: new Set([
+        'and', 'as', 'assert', 'async', 'await', 'break', 'class', 'continue', 'def',
+        'del', 'elif', 'else', 'except', 'finally', 'for', 'from', 'global', 'if',
+        'import', 'in', 'is', 'lambda', 'nonlocal', 'not', 'or', 'pass', 'raise',
+        'return', 'try', 'while', 'with', 'yield'
+    ])
+};
+
+/**
+ * Closing brackets are counted with their opening bracket as a single operator
+ */
+const CLOSING_BRACKETS = new Set([')', ']', '}']);
+
+export class HalsteadCalculator {
+    private keywords: Set<string>;
+
+    constructor(language: Language) {
+        this.keywords = KEYWORDS[language];
+    }
+
+    /**
+     * Calculate Halstead metrics for a token sequence
+     *
+     * @param tokens - Code tokens (comments/whitespace already filtered)
+     */
+    calculate(tokens: string[]): HalsteadMetrics {
+        const operators = new Map<string, number>();
+        const operands = new Map<string, number>();
+
+        for (const token of tokens) {
+            if (CLOSING_BRACKETS.has(token)) {
+                continue;
+            }
+
+            const bucket = this.isOperand(token) ? operands : operators;
+            bucket.set(token, (bucket.get(token) || 0) + 1);
+        }
+
+        const distinctOperators = operators.size;
+        const distinctOperands = operands.size;
+        const totalOperators = this.sum(operators);
+        const totalOperands = this.sum(operands);
+
+        const vocabulary = distinctOperators + distinctOperands;
+        const length = totalOperators + totalOperands;
+        const volume = vocabulary > 0 ? length * Math.log2(vocabulary) : 0;
+        const difficulty = distinctOperands > 0
+            ? (distinctOperators / 2) * (totalOperands / distinctOperands)
+            : 0;
+
+        return {
+            distinctOperators,
+            distinctOperands,
+            totalOperators,
+            totalOperands,
+            vocabulary,
+            length,
+            volume,
+            difficulty,
+            effort: difficulty * volume
+        };
+    }
+
+    /**
+     * Maintainability index (0-100, higher is easier to maintain)
+     *
+     * @param volume - Halstead volume
+     * @param ccn - Cyclomatic complexity
+     * @param nloc - Lines of code
+     */
+    static maintainabilityIndex(volume: number, ccn: number, nloc: number): number {
+        // ln(0) is undefined; treat empty code as a single line/unit
+        const raw = 171
+            - 5.2 * Math.log(Math.max(1, volume))
+            - 0.23 * ccn
+            - 16.2 * Math.log(Math.max(1, nloc));
+
+        return Math.round(Math.max(0, raw * 100 / 171));
+    }
+
+    /**
+     * Maintainability index of a package or directory: the mean of its
+     * functions' indexes, weighted by NLOC so one-line helpers don't mask a
+     * large unmaintainable function
+     *
+     * @returns Index 0-100, or undefined when no function has one
+     */
+    static aggregateMaintainabilityIndex(functions: FunctionInfo[]): number | undefined {
+        let weighted = 0;
+        let lines = 0;
+        for (const func of functions) {
+            if (func.maintainabilityIndex !== undefined) {
+                const weight = Math.max(1, func.nloc);
+                weighted += func.maintainabilityIndex * weight;
+                lines += weight;
+            }
+        }
+        return lines > 0 ? Math.round(weighted / lines) : undefined;
+    }
+
+    /**
+     * Get human-readable maintainability level
+     * Uses Visual Studio's bands: 0-9 low, 10-19 moderate, 20-100 high
+     */
+    static getMaintainabilityLevel(index: number): string {
+        if (index < 10) return 'Low';
+        if (index < 20) return 'Moderate';
+        return 'High';
+    }
+
+    /**
+     * Check if a token is a reserved word in this language
+     */
+    isKeyword(token: string): boolean {
+        return this.keywords.has(token);
+    }
+
+    /**
+     * Identifiers and literals are operands; keywords and symbols are operators
+     */
+    private isOperand(token: string): boolean {
+        if (this.isKeyword(token)) {
+            return false;
+        }
+        return /^\w/.test(token) || /^["'`]/.test(token);
+    }
+
+    private sum(counts: Map<string, number>): number {
+        let total = 0;
+        for (const count of counts.values()) {
+            total += count;
+        }
+        return total;
+    }
+}
@@ -1,7 +1,7 @@
 /**
  * Complexity Analysis Module
  *
- * Provides cyclomatic complexity analysis for code segments
+ * Provides cyclomatic, cognitive and Halstead complexity analysis for code segments
  * Based on Lizard's approach with Phase 1 implementation
  *
  * @module complexity
@@ -10,11 +10,14 @@
 export { Tokenizer } from './tokenizer';
 export { ComplexityAnalyzer } from './analyzer';
 export { ComplexityScorer } from './scorer';
+export { CognitiveComplexityCalculator } from './cognitiveComplexity';
+export { HalsteadCalculator } from './halstead';
 
 export type {
     FunctionInfo,
     FileComplexityInfo,
-    ComplexityScore
+    ComplexityScore,
+    HalsteadMetrics
 } from './types';
 
 export { Language } from './types';
@@ -46,7 +49,7 @@ export function scoreSegment(code: string, filename: string) {
  *
  * @param code - Source code to analyze
  * @param filename - Filename for language detection
- * @returns Detailed analysis with CCN, NLOC, token count
+ * @returns Detailed analysis with CCN, cognitive complexity, NLOC, token count
  *
  * @example
  * ```typescript
@@ -100,9 +100,9 @@ export class PythonStateMachine extends StateMachine {
 
         // Check for docstring (triple-quoted string)
         if (token.startsWith('"""') || token.startsWith("'''")) {
-            // Docstrings don't count toward NLOC
-            const newlineCount = (token.match(/\n/g) || []).length;
-            this.context.addNloc(-(newlineCount + 1));
+            // Docstrings don't count toward NLOC; a multi-line one is a single
+            // token, so only its first line was counted
+            this.context.excludeTokenFromNloc();
         }
 
         // Process the token in global state
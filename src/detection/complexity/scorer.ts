@@ -10,7 +10,7 @@
  */
 
 import { ComplexityAnalyzer, AnalysisResult } from './analyzer';
-import { ComplexityScore } from './types';
+import { ComplexityScore, ComplexityLevelThresholds, FunctionInfo } from './types';
 
 /**
  * Scoring thresholds and weights
@@ -37,7 +37,15 @@ const SCORING_CONFIG = {
     weights: {
         ccn: 0.7,     // CCN is primary indicator (70%)
         size: 0.3     // Code size is secondary (30%)
-    }
+    },
+
+    /** Default score cutoffs for complexity levels */
+    levels: {
+        low: 3,       // 1-3: Low
+        medium: 6,    // 4-6: Medium
+        high: 8       // 7-8: High
+                      // 9-10: Very High
+    } as ComplexityLevelThresholds
 };
 
 /**
@@ -84,6 +92,8 @@ export class ComplexityScorer {
         return {
             score: finalScore,
             ccn: analysis.totalCCN,
+            cognitive: analysis.totalCognitive,
+            maintainabilityIndex: analysis.maintainabilityIndex,
             nloc: analysis.nloc,
             functionCount: analysis.functions.length,
             breakdown: {
@@ -93,6 +103,23 @@ export class ComplexityScorer {
         };
     }
 
+    /**
+     * Score a single function from the analyzer
+     */
+    static scoreFunction(func: FunctionInfo): ComplexityScore {
+        return this.scoreAnalysis({
+            totalCCN: func.cyclomaticComplexity,
+            totalCognitive: func.cognitiveComplexity,
+            nloc: func.nloc,
+            tokenCount: func.tokenCount,
+            decisionPoints: func.cyclomaticComplexity - 1,
+            // Set by the analyzer for every completed function
+            halstead: func.halstead!,
+            maintainabilityIndex: func.maintainabilityIndex!,
+            functions: [func]
+        });
+    }
+
     /**
      * Map CCN to 1-10 score
      *
@@ -169,11 +196,17 @@ export class ComplexityScorer {
 
     /**
      * Get human-readable complexity level
+     *
+     * @param score - Complexity score (1-10)
+     * @param levels - Score cutoffs, defaults to the built-in Low/Medium/High bands
      */
-    static getComplexityLevel(score: number): string {
-        if (score <= 3) return 'Low';
-        if (score <= 6) return 'Medium';
-        if (score <= 8) return 'High';
+    static getComplexityLevel(
+        score: number,
+        levels: ComplexityLevelThresholds = SCORING_CONFIG.levels
+    ): string {
+        if (score <= levels.low) return 'Low';
+        if (score <= levels.medium) return 'Medium';
+        if (score <= levels.high) return 'High';
         return 'Very High';
     }
 
@@ -79,7 +79,7 @@ export abstract class StateMachine {
 
         // Track condition keywords for CCN
         if (this.conditions.has(token)) {
-            this.context.addCondition(1);
+            this.context.addCondition(1, token);
         }
 
         this.lastToken = token;
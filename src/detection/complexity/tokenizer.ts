@@ -122,21 +122,26 @@ export class Tokenizer {
      * Useful for token counting
      */
     static filterCodeTokens(tokens: string[]): string[] {
-        return tokens.filter(token => {
-            // Skip whitespace
-            if (/^\s+$/.test(token)) {
-                return false;
-            }
+        return tokens.filter(token => Tokenizer.isCodeToken(token));
+    }
 
-            // Skip comments
-            if (token.startsWith('//') ||
-                token.startsWith('/*') ||
-                token.startsWith('#')) {
-                return false;
-            }
+    /**
+     * Check if a token is code (not whitespace or a comment)
+     */
+    static isCodeToken(token: string): boolean {
+        // Skip whitespace
+        if (/^\s+$/.test(token)) {
+            return false;
+        }
+
+        // Skip comments
+        if (token.startsWith('//') ||
+            token.startsWith('/*') ||
+            token.startsWith('#')) {
+            return false;
+        }
 
-            return true;
-        });
+        return true;
     }
 
     /**
@@ -23,6 +23,7 @@ export class TypeScriptStateMachine extends StateMachine {
     private staticSeen: boolean = false;
     private asyncSeen: boolean = false;
     private prevToken: string = '';
+    private parameterParenDepth: number = 0;
 
     constructor(context: FunctionContext) {
         super(context);
@@ -309,10 +310,22 @@ export class TypeScriptStateMachine extends StateMachine {
      * Function parameter declaration state
      */
     private parameterState(token: string): void {
-        if (token === ')') {
+        // Parentheses inside the list (defaults, function types) belong to a parameter
+        if (token === '(') {
+            this.parameterParenDepth++;
+            if (this.parameterParenDepth > 1) {
+                this.context.addParameter(token, true);
+                return;
+            }
+        } else if (token === ')') {
+            this.parameterParenDepth--;
+            if (this.parameterParenDepth > 0) {
+                this.context.addParameter(token, true);
+                return;
+            }
             this.state = this.expectingFunctionBody.bind(this);
-        } else if (token !== '(') {
-            this.context.addParameter(token);
+        } else {
+            this.context.addParameter(token, true);
             return;
         }
         this.context.addToLongFunctionName(' ' + token);
@@ -22,6 +22,9 @@ export interface FunctionInfo {
     /** Cyclomatic Complexity Number (CCN) */
     cyclomaticComplexity: number;
 
+    /** Cognitive complexity (nesting-weighted, see cognitiveComplexity.ts) */
+    cognitiveComplexity: number;
+
     /** Number of tokens in function */
     tokenCount: number;
 
@@ -31,8 +34,83 @@ export interface FunctionInfo {
     /** Number of parameters */
     parameterCount: number;
 
+    /** Number of type parameters (Go generics), not included in parameterCount */
+    typeParameterCount?: number;
+
     /** Maximum nesting depth */
     maxNestingDepth: number;
+
+    /** Decision points that make up cyclomaticComplexity, beyond its base of 1 */
+    decisionPoints?: DecisionPoint[];
+
+    /** Constructs that make up cognitiveComplexity (set once the function is complete) */
+    cognitiveIncrements?: CognitiveIncrement[];
+
+    /** Halstead operator/operand metrics (set once the function is complete) */
+    halstead?: HalsteadMetrics;
+
+    /** Maintainability index 0-100 (set once the function is complete) */
+    maintainabilityIndex?: number;
+}
+
+/**
+ * One +1 to cyclomatic complexity
+ */
+export interface DecisionPoint {
+    /** Line of the token (0-indexed) */
+    line: number;
+
+    /** Condition token, e.g. 'if', 'case', '&&' */
+    construct: string;
+}
+
+/**
+ * One construct's contribution to cognitive complexity
+ */
+export interface CognitiveIncrement {
+    /** Line of the construct (0-indexed) */
+    line: number;
+
+    /** Keyword or operator, e.g. 'if', '&&', 'else if', '?:' or 'recursion' */
+    construct: string;
+
+    /** Amount added, including the nesting penalty */
+    increment: number;
+
+    /** Nesting penalty included in the increment */
+    nesting: number;
+}
+
+/**
+ * Halstead metrics for a function or code segment
+ */
+export interface HalsteadMetrics {
+    /** Distinct operators (n1) */
+    distinctOperators: number;
+
+    /** Distinct operands (n2) */
+    distinctOperands: number;
+
+    /** Total operators (N1) */
+    totalOperators: number;
+
+    /** Total operands (N2) */
+    totalOperands: number;
+
+    /** Program vocabulary (n1 + n2) */
+    vocabulary: number;
+
+    /** Program length (N1 + N2) */
+    length: number;
+
+    /** Volume (V) */
+    volume: number;
+
+    /** Difficulty (D) */
+    difficulty: number;
+
+    /** Effort (E) */
+    effort: number;
 }
 
 /**
@@ -75,6 +153,12 @@ export interface ComplexityScore {
     /** Raw CCN value */
     ccn: number;
 
+    /** Raw cognitive complexity value */
+    cognitive: number;
+
+    /** Maintainability index (0-100, higher is better) */
+    maintainabilityIndex: number;
+
     /** Lines of code */
     nloc: number;
 
@@ -87,3 +171,14 @@ export interface ComplexityScore {
         sizeScore: number;
     };
 }
+
+/**
+ * Score cutoffs for complexity levels
+ * A score at or below `low` is Low, at or below `medium` is Medium,
+ * at or below `high` is High, and anything above is Very High
+ */
+export interface ComplexityLevelThresholds {
+    low: number;
+    medium: number;
+    high: number;
+}
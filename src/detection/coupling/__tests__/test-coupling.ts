@@ -0,0 +1,138 @@
+/**
+ * Test for PackageGraphBuilder
+ *
+ * Run with: npx ts-node src/detection/coupling/__tests__/test-coupling.ts
+ */
+
+import { PackageGraphBuilder } from '../packageGraphBuilder';
+import { createChecks } from '../../../__tests__/checks';
+
+const files: Record<string, string> = {
+    'domain/order.go': `package domain
+
+// Repository stores orders.
+type Repository interface {
+    Save(o Order) error
+}
+
+type (
+    Order struct {
+        ID    string
+        Items []Item
+    }
+    Item = string
+)
+`,
+    'store/store.go': `package store
+
+import "example.com/shop/domain"
+
+type Store struct {
+    orders map[string]domain.Order
+}
+
+func New() *Store {
+    return &Store{orders: map[string]domain.Order{}}
+}
+`,
+    'api/handler.go': `package api
+
+import (
+    "net/http"
+
+    "example.com/shop/domain"
+    st "example.com/shop/store"
+)
+
+type Handler struct {
+    repo domain.Repository
+}
+
+func NewHandler() *Handler {
+    _ = st.New()
+    return &Handler{}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    var o domain.Order
+    _ = h.repo.Save(o)
+}
+`,
+    'api/handler_test.go': `package api
+
+import "example.com/shop/cmd/server"
+`,
+    'internal/version/version.go': `package version
+
+func String() string {
+    return "1.0"
+}
+`,
+    'cmd/server/main.go': `package main
+
+import (
+    "log"
+
+    "example.com/shop/api"
+)
+
+func main() {
+    log.Fatal(api.NewHandler())
+}
+`
+};
+
+function testPackageGraph(): boolean {
+    console.log('\n=== Testing PackageGraphBuilder ===\n');
+    const { check, finish } = createChecks();
+    const round = (n: number) => Math.round(n * 100) / 100;
+
+    const build = (modulePath?: string) => {
+        const builder = new PackageGraphBuilder(modulePath);
+        for (const [filePath, sourceCode] of Object.entries(files)) {
+            builder.addFile(filePath, sourceCode);
+        }
+        return builder.build();
+    };
+
+    const graph = build('example.com/shop');
+    const pkg = (directory: string) => graph.packages.find(p => p.directory === directory)!;
+    const metrics = (directory: string) => {
+        const p = pkg(directory);
+        const optional = (n?: number) => (n === undefined ? null : round(n));
+        return [p.afferentCoupling, p.efferentCoupling, optional(p.instability), round(p.abstractness), optional(p.distance)];
+    };
+
+    // Test 1: coupling from imports inside the module
+    console.log('Test 1: Ca, Ce, I, A, D');
+    check('domain (stable, one interface among three types)', metrics('domain'), [2, 0, 0, 0.33, 0.67]);
+    check('store', metrics('store'), [1, 1, 0.5, 0, 0.5]);
+    check('api (stdlib and test imports ignored)', metrics('api'), [1, 2, 0.67, 0, 0.33]);
+    check('cmd/server (fully unstable, on the main sequence)', metrics('cmd/server'), [0, 1, 1, 0, 0]);
+    check('furthest from the main sequence first', graph.packages[0].directory, 'domain');
+    check('isolated package has no I or D', metrics('internal/version'), [0, 0, null, 0, null]);
+    check('isolated package ranks last', graph.packages[graph.packages.length - 1].directory, 'internal/version');
+
+    // Test 2: exported symbol usage, including aliased imports
+    console.log('\nTest 2: Symbols');
+    const symbols = (from: string, to: string) =>
+        graph.dependencies.find(d => d.from === pkg(from).id && d.to === pkg(to).id)?.symbols;
+    check('api uses domain', symbols('api', 'domain'), ['Order', 'Repository']);
+    check('api uses store through an alias', symbols('api', 'store'), ['New']);
+    check('package IDs are import paths', pkg('cmd/server').id, 'example.com/shop/cmd/server');
+    check('package MI from its functions', pkg('api').maintainabilityIndex !== undefined && pkg('api').maintainabilityIndex! > 0, true);
+    check('no MI without functions', pkg('domain').maintainabilityIndex, undefined);
+    check('function metrics', [pkg('api').functionCount, pkg('api').mostComplexFunction?.name], [2, 'NewHandler']);
+
+    // Test 3: resolution without go.mod, and output
+    console.log('\nTest 3: Without a module path');
+    const fallback = build();
+    check('same dependencies by directory suffix', fallback.dependencies.length, graph.dependencies.length);
+    const dot = PackageGraphBuilder.toDot(graph);
+    check('DOT has the api -> store edge', dot.includes('"example.com/shop/api" -> "example.com/shop/store" [label="1"];'), true);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testPackageGraph() ? 0 : 1);
@@ -0,0 +1,38 @@
+/**
+ * Coupling Module
+ *
+ * Package-level coupling, instability and abstractness for Go modules
+ *
+ * @module coupling
+ */
+
+export { PackageGraphBuilder } from './packageGraphBuilder';
+
+export type {
+    PackageGraph,
+    PackageMetrics,
+    PackageDependency,
+    PackageFunction
+} from './types';
+
+/**
+ * Quick API: Build a package graph over a set of Go files
+ *
+ * @param files - Map of module-relative file path to contents
+ * @param modulePath - Module path from go.mod, if known
+ * @returns Graph with packages ordered by distance from the main sequence
+ *
+ * @example
+ * ```typescript
+ * const graph = buildPackageGraph(files, 'example.com/shop');
+ * graph.packages.forEach(p => console.log(`${p.id}: D=${p.distance?.toFixed(2) ?? '-'} A=${p.abstractness.toFixed(2)}`));
+ * ```
+ */
+export function buildPackageGraph(files: Map<string, string>, modulePath?: string) {
+    const { PackageGraphBuilder } = require('./packageGraphBuilder');
+    const builder = new PackageGraphBuilder(modulePath);
+    for (const [filePath, sourceCode] of files) {
+        builder.addFile(filePath, sourceCode);
+    }
+    return builder.build();
+}
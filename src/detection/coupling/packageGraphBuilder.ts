@@ -0,0 +1,378 @@
+/**
+ * Package Graph Builder
+ * Coupling, instability and abstractness per Go package
+ *
+ * Packages are directories. Imports of packages inside the module become
+ * dependencies; the standard library and third-party modules are left out,
+ * so Ca and Ce only count packages in the module. With the module path from
+ * go.mod, import paths map straight to directories; without it, an import
+ * resolves to the unique directory it ends with.
+ *
+ * Exported symbol usage is found lexically: pkg.Name where pkg is the local
+ * name of an import. Test files are skipped, since their imports don't
+ * couple the package itself. A package with no coupling inside the module
+ * has no instability or distance, so it isn't ranked as far from the main
+ * sequence. Each package also gets totals over its functions: count,
+ * cognitive complexity, the most complex one, and the NLOC-weighted
+ * maintainability index.
+ */
+
+import * as path from 'path';
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { HalsteadCalculator } from '../complexity/halstead';
+import { Tokenizer } from '../complexity/tokenizer';
+import { FunctionInfo } from '../complexity/types';
+import { PackageDependency, PackageFunction, PackageGraph, PackageMetrics } from './types';
+
+/**
+ * A code token and whether a line break precedes it
+ */
+interface LineToken {
+    text: string;
+    newline: boolean;
+}
+
+interface ImportSpec {
+    path: string;
+    alias?: string;
+}
+
+/**
+ * What one file contributes to its package
+ */
+interface ParsedFile {
+    packageName: string;
+    imports: ImportSpec[];
+    abstractTypes: number;
+    concreteTypes: number;
+    /** Local name to the exported identifiers selected from it */
+    selectors: Map<string, Set<string>>;
+    functions: FunctionInfo[];
+}
+
+/**
+ * Order for the distance ranking: furthest first, packages without a distance last
+ */
+function byDistance(a: PackageMetrics, b: PackageMetrics): number {
+    if (a.distance === undefined || b.distance === undefined) {
+        return (a.distance === undefined ? 1 : 0) - (b.distance === undefined ? 1 : 0);
+    }
+    return b.distance - a.distance;
+}
+
+const OPENERS = new Set(['(', '[', '{']);
+const CLOSERS = new Set([')', ']', '}']);
+
+export class PackageGraphBuilder {
+    private files: Array<{ filePath: string; directory: string; parsed: ParsedFile }> = [];
+    private modulePath?: string;
+
+    /**
+     * @param modulePath - Module path from go.mod (e.g. example.com/shop), if known
+     */
+    constructor(modulePath?: string) {
+        this.modulePath = modulePath;
+    }
+
+    /**
+     * Add a Go source file
+     *
+     * @param filePath - Path relative to the module root; its directory is the package
+     * @param sourceCode - Full file contents
+     */
+    addFile(filePath: string, sourceCode: string): void {
+        const normalized = filePath.replace(/\\/g, '/');
+        if (!normalized.endsWith('.go') || normalized.endsWith('_test.go')) {
+            return;
+        }
+
+        const parsed = this.parse(sourceCode);
+        parsed.functions = ComplexityAnalyzer.forFile(normalized).analyze(sourceCode).functions
+            .filter(func => func.name !== '*global*');
+        if (parsed.packageName) {
+            this.files.push({ filePath: normalized, directory: path.posix.dirname(normalized), parsed });
+        }
+    }
+
+    /**
+     * Resolve imports and compute package metrics
+     *
+     * @returns Graph over every package with at least one added file
+     */
+    build(): PackageGraph {
+        const packages = new Map<string, PackageMetrics>();
+        for (const file of this.files) {
+            const existing = packages.get(file.directory);
+            if (existing) {
+                existing.files.push(file.filePath);
+                existing.abstractTypes += file.parsed.abstractTypes;
+                existing.concreteTypes += file.parsed.concreteTypes;
+                continue;
+            }
+            packages.set(file.directory, {
+                id: this.packageId(file.directory),
+                directory: file.directory,
+                name: file.parsed.packageName,
+                files: [file.filePath],
+                abstractTypes: file.parsed.abstractTypes,
+                concreteTypes: file.parsed.concreteTypes,
+                afferentCoupling: 0,
+                efferentCoupling: 0,
+                abstractness: 0,
+                functionCount: 0,
+                cognitiveComplexity: 0,
+                dependencies: [],
+                dependents: []
+            });
+        }
+
+        // One dependency per package pair, with the symbols used across all files
+        const dependencies = new Map<string, PackageDependency>();
+        for (const file of this.files) {
+            const from = packages.get(file.directory)!;
+            for (const spec of file.parsed.imports) {
+                const to = this.resolve(spec.path, packages);
+                if (!to || to === from) {
+                    continue;
+                }
+
+                const key = `${from.id}\n${to.id}`;
+                const dependency = dependencies.get(key) || { from: from.id, to: to.id, symbols: [] };
+                const localName = spec.alias && spec.alias !== '_' && spec.alias !== '.' ? spec.alias : to.name;
+                const used = spec.alias === '_' || spec.alias === '.' ? [] : [...(file.parsed.selectors.get(localName) || [])];
+                dependency.symbols = [...new Set([...dependency.symbols, ...used])].sort();
+                dependencies.set(key, dependency);
+            }
+        }
+
+        const byId = new Map([...packages.values()].map(pkg => [pkg.id, pkg]));
+        for (const dependency of dependencies.values()) {
+            byId.get(dependency.from)!.dependencies.push(dependency.to);
+            byId.get(dependency.to)!.dependents.push(dependency.from);
+        }
+
+        for (const pkg of packages.values()) {
+            pkg.dependencies.sort();
+            pkg.dependents.sort();
+            pkg.afferentCoupling = pkg.dependents.length;
+            pkg.efferentCoupling = pkg.dependencies.length;
+
+            const coupling = pkg.afferentCoupling + pkg.efferentCoupling;
+            const types = pkg.abstractTypes + pkg.concreteTypes;
+            pkg.abstractness = types > 0 ? pkg.abstractTypes / types : 0;
+            if (coupling > 0) {
+                pkg.instability = pkg.efferentCoupling / coupling;
+                pkg.distance = Math.abs(pkg.abstractness + pkg.instability - 1);
+            }
+
+            const functions: PackageFunction[] = [];
+            for (const file of this.files.filter(f => f.directory === pkg.directory)) {
+                functions.push(...file.parsed.functions.map(func => ({
+                    name: func.name,
+                    filePath: file.filePath,
+                    startLine: func.startLine,
+                    cognitiveComplexity: func.cognitiveComplexity
+                })));
+            }
+            pkg.functionCount = functions.length;
+            pkg.cognitiveComplexity = functions.reduce((sum, func) => sum + func.cognitiveComplexity, 0);
+            pkg.mostComplexFunction = functions.reduce<PackageFunction | undefined>(
+                (top, func) => (!top || func.cognitiveComplexity > top.cognitiveComplexity ? func : top), undefined);
+            pkg.maintainabilityIndex = HalsteadCalculator.aggregateMaintainabilityIndex(
+                this.files.filter(f => f.directory === pkg.directory).flatMap(f => f.parsed.functions));
+        }
+
+        return {
+            packages: [...packages.values()].sort((a, b) => byDistance(a, b) || a.id.localeCompare(b.id)),
+            dependencies: [...dependencies.values()]
+        };
+    }
+
+    /**
+     * Render a graph in Graphviz DOT format
+     */
+    static toDot(graph: PackageGraph): string {
+        const lines = ['digraph packages {', '    rankdir=LR;', '    node [shape=box];'];
+
+        const fixed = (n?: number) => (n === undefined ? '-' : n.toFixed(2));
+        for (const pkg of graph.packages) {
+            const label = `${pkg.name}\n${pkg.directory}\n` +
+                `Ca ${pkg.afferentCoupling} · Ce ${pkg.efferentCoupling}\n` +
+                `I ${fixed(pkg.instability)} · A ${fixed(pkg.abstractness)} · D ${fixed(pkg.distance)}\n` +
+                `${pkg.functionCount} functions · cognitive ${pkg.cognitiveComplexity}` +
+                (pkg.maintainabilityIndex !== undefined ? ` · MI ${pkg.maintainabilityIndex}` : '');
+            lines.push(`    ${JSON.stringify(pkg.id)} [label=${JSON.stringify(label)}];`);
+        }
+        for (const dependency of graph.dependencies) {
+            const label = dependency.symbols.length > 0 ? ` [label="${dependency.symbols.length}"]` : '';
+            lines.push(`    ${JSON.stringify(dependency.from)} -> ${JSON.stringify(dependency.to)}${label};`);
+        }
+
+        lines.push('}');
+        return lines.join('\n');
+    }
+
+    /**
+     * Render a graph as JSON
+     */
+    static toJson(graph: PackageGraph): string {
+        return JSON.stringify(graph, null, 2);
+    }
+
+    private packageId(directory: string): string {
+        if (!this.modulePath) {
+            return directory;
+        }
+        return directory === '.' ? this.modulePath : `${this.modulePath}/${directory}`;
+    }
+
+    /**
+     * Find the package an import path refers to, or undefined when outside the module
+     */
+    private resolve(importPath: string, packages: Map<string, PackageMetrics>): PackageMetrics | undefined {
+        if (this.modulePath) {
+            if (importPath === this.modulePath) {
+                return packages.get('.');
+            }
+            return importPath.startsWith(this.modulePath + '/')
+                ? packages.get(importPath.slice(this.modulePath.length + 1))
+                : undefined;
+        }
+
+        // Without go.mod, the longest directory the import path ends with wins
+        const matches = [...packages.keys()]
+            .filter(dir => dir !== '.' && (importPath === dir || importPath.endsWith('/' + dir)))
+            .sort((a, b) => b.length - a.length);
+        if (matches.length === 0 || (matches.length > 1 && matches[0].length === matches[1].length)) {
+            return undefined;
+        }
+        return packages.get(matches[0]);
+    }
+
+    /**
+     * Read the package clause, imports, type declarations and selectors of a file
+     */
+    private parse(sourceCode: string): ParsedFile {
+        const tokens: LineToken[] = [];
+        let newline = false;
+        for (const token of Tokenizer.generateTokens(sourceCode)) {
+            if (Tokenizer.isCodeToken(token)) {
+                tokens.push({ text: token, newline });
+                newline = false;
+            } else if (token.includes('\n')) {
+                newline = true;
+            }
+        }
+
+        const parsed: ParsedFile = { packageName: '', imports: [], abstractTypes: 0, concreteTypes: 0, selectors: new Map(), functions: [] };
+        const text = (i: number) => tokens[i]?.text ?? '';
+        let depth = 0;
+
+        for (let i = 0; i < tokens.length; i++) {
+            const token = text(i);
+
+            // pkg.Exported, but only the first selector of a chain
+            if (/^[A-Za-z_]\w*$/.test(token) && text(i + 1) === '.' && /^[A-Z]\w*$/.test(text(i + 2)) && text(i - 1) !== '.') {
+                parsed.selectors.set(token, (parsed.selectors.get(token) || new Set()).add(text(i + 2)));
+            }
+
+            if (depth === 0 && token === 'package' && !parsed.packageName) {
+                parsed.packageName = text(i + 1);
+            } else if (depth === 0 && token === 'import') {
+                i = this.readGroup(tokens, i + 1, start => {
+                    let alias: string | undefined;
+                    for (let j = start; j < tokens.length; j++) {
+                        if (/^["`]/.test(text(j))) {
+                            parsed.imports.push({ path: text(j).slice(1, -1), alias });
+                            return;
+                        }
+                        alias = text(j);
+                    }
+                });
+                continue;
+            } else if (depth === 0 && token === 'type') {
+                i = this.readGroup(tokens, i + 1, start => {
+                    if (this.declaredKind(tokens, start) === 'interface') {
+                        parsed.abstractTypes++;
+                    } else {
+                        parsed.concreteTypes++;
+                    }
+                });
+                continue;
+            }
+
+            if (OPENERS.has(token)) {
+                depth++;
+            } else if (CLOSERS.has(token)) {
+                depth = Math.max(0, depth - 1);
+            }
+        }
+
+        return parsed;
+    }
+
+    /**
+     * Visit the specs of an import or type declaration, grouped in ( ) or single
+     * Specs in a group start on a new line or after ';'
+     *
+     * @returns Index to resume scanning from: the closing ')' of a group, or the start of a single spec
+     */
+    private readGroup(tokens: LineToken[], start: number, visit: (specStart: number) => void): number {
+        if (tokens[start]?.text !== '(') {
+            visit(start);
+            return start;
+        }
+
+        let depth = 0;
+        for (let j = start + 1; j < tokens.length; j++) {
+            const token = tokens[j].text;
+            if (depth === 0 && token === ')') {
+                return j;
+            }
+            if (depth === 0 && token !== ';' && (j === start + 1 || tokens[j].newline || tokens[j - 1].text === ';')) {
+                visit(j);
+            }
+            if (OPENERS.has(token)) {
+                depth++;
+            } else if (CLOSERS.has(token)) {
+                depth--;
+            }
+        }
+        return tokens.length;
+    }
+
+    /**
+     * The first token of a type spec's definition: Name [TypeParams] [=] Type
+     */
+    private declaredKind(tokens: LineToken[], start: number): string {
+        let i = start + 1;
+
+        // [T any] is a type parameter list; [4]int and []int are array and slice types
+        if (tokens[i]?.text === '[') {
+            let depth = 0;
+            let inside = 0;
+            let j = i;
+            for (; j < tokens.length; j++) {
+                if (tokens[j].text === '[') {
+                    depth++;
+                } else if (tokens[j].text === ']') {
+                    depth--;
+                    if (depth === 0) {
+                        break;
+                    }
+                }
+                if (depth >= 1 && j > i) {
+                    inside++;
+                }
+            }
+            if (inside >= 2 && /^[A-Za-z_]\w*$/.test(tokens[i + 1].text)) {
+                i = j + 1;
+            }
+        }
+
+        if (tokens[i]?.text === '=') {
+            i++;
+        }
+        return tokens[i]?.text ?? '';
+    }
+}
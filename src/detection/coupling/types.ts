@@ -0,0 +1,93 @@
+/**
+ * Type definitions for package coupling
+ */
+
+/**
+ * Coupling and abstractness of one Go package (Robert C. Martin's package metrics)
+ */
+export interface PackageMetrics {
+    /** Import path when the module path is known, else the directory */
+    id: string;
+
+    /** Directory relative to the module root ('.' for the root package) */
+    directory: string;
+
+    /** Name from the package clause */
+    name: string;
+
+    /** Non-test files in the package */
+    files: string[];
+
+    /** Interface types declared in the package */
+    abstractTypes: number;
+
+    /** Every other named type declared in the package */
+    concreteTypes: number;
+
+    /** Ca: packages in the module that import this one */
+    afferentCoupling: number;
+
+    /** Ce: packages in the module this one imports */
+    efferentCoupling: number;
+
+    /** I = Ce / (Ca + Ce), 0 (stable) to 1 (unstable); undefined when Ca + Ce = 0 */
+    instability?: number;
+
+    /** A = abstract / all named types, 0 (concrete) to 1 (abstract) */
+    abstractness: number;
+
+    /** D = |A + I - 1|, distance from the main sequence; high means rigid or useless. Undefined with I */
+    distance?: number;
+
+    /** NLOC-weighted maintainability index of the package's functions, undefined without functions */
+    maintainabilityIndex?: number;
+
+    /** Functions declared in the package */
+    functionCount: number;
+
+    /** Sum of cognitive complexity over the package's functions */
+    cognitiveComplexity: number;
+
+    /** The function with the highest cognitive complexity */
+    mostComplexFunction?: PackageFunction;
+
+    /** IDs of packages imported */
+    dependencies: string[];
+
+    /** IDs of packages importing this one */
+    dependents: string[];
+}
+
+/**
+ * A function of a package, for linking package metrics to function metrics
+ */
+export interface PackageFunction {
+    name: string;
+    filePath: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    cognitiveComplexity: number;
+}
+
+/**
+ * An import of one package in the module by another
+ */
+export interface PackageDependency {
+    from: string;
+    to: string;
+
+    /** Exported identifiers of the imported package referenced by the importer */
+    symbols: string[];
+}
+
+/**
+ * Package graph over every added file
+ */
+export interface PackageGraph {
+    /** Packages, furthest from the main sequence first; packages without coupling last */
+    packages: PackageMetrics[];
+
+    dependencies: PackageDependency[];
+}
@@ -5,6 +5,7 @@ import { PasteDetector } from './pasteDetector';
 import { BlockManager } from '../ui/blockManager';
 import { DebugLogger } from '../debug/debugLogger';
 import { FilePatternMatcher } from '../utils/filePatternMatcher';
+import { ComplexityThresholds } from '../utils/complexityThresholds';
 import { FileRegistry } from '../tracking/fileRegistry';
 import { StalenessValidator } from './stalenessValidator';
 import { hashContent, getContentAtLines } from '../utils/contentHash';
@@ -24,10 +25,11 @@ export class DetectionCoordinator {
 
     constructor(
         fileRegistry: FileRegistry,
+        complexityThresholds: ComplexityThresholds,
         blockManager?: BlockManager,
         debugLogger?: DebugLogger
     ) {
-        this._changeDetector = new ChangeDetector();
+        this._changeDetector = new ChangeDetector(complexityThresholds);
         this._pasteDetector = new PasteDetector();
         this._blockManager = blockManager;
         this._debugLogger = debugLogger;
@@ -51,8 +53,8 @@ export class DetectionCoordinator {
             return;
         }
 
-        // Check if file should be excluded (handles dotfiles and patterns)
-        if (this._filePatternMatcher.shouldExclude(document.fileName)) {
+        // Check if file should be excluded (handles dotfiles, patterns and generated code)
+        if (this._filePatternMatcher.shouldExclude(document.fileName, this.fileHeader(document))) {
             Logger.debug(`DetectionCoordinator: File excluded by pattern: ${document.fileName}`);
             return;
         }
@@ -84,8 +86,8 @@ export class DetectionCoordinator {
 
         const filePath = event.document.fileName;
 
-        // Check if file should be excluded (handles dotfiles and patterns)
-        if (this._filePatternMatcher.shouldExclude(filePath)) {
+        // Check if file should be excluded (handles dotfiles, patterns and generated code)
+        if (this._filePatternMatcher.shouldExclude(filePath, this.fileHeader(event.document))) {
             Logger.debug(`DetectionCoordinator: Change event ignored for excluded file: ${filePath}`);
             return;
         }
@@ -275,8 +277,8 @@ export class DetectionCoordinator {
         Logger.debug(`File: ${filePath}`);
         Logger.debug(`Lines: ${document.lineCount}`);
 
-        // Check if file should be excluded (handles dotfiles and patterns)
-        if (this._filePatternMatcher.shouldExclude(filePath)) {
+        // Check if file should be excluded (handles dotfiles, patterns and generated code)
+        if (this._filePatternMatcher.shouldExclude(filePath, this.fileHeader(document))) {
             Logger.debug(`DetectionCoordinator: File excluded by pattern: ${filePath}`);
             return;
         }
@@ -342,4 +344,11 @@ export class DetectionCoordinator {
     public getChangeDetector(): ChangeDetector {
         return this._changeDetector;
     }
+
+    /**
+     * First lines of a document, enough to find a generated-code header
+     */
+    private fileHeader(document: vscode.TextDocument): string {
+        return document.getText(new vscode.Range(0, 0, 50, 0));
+    }
 }
@@ -0,0 +1,160 @@
+/**
+ * Test for GrowthEstimator
+ *
+ * Run with: npx ts-node src/detection/growth/__tests__/test-growth.ts
+ */
+
+import * as fs from 'fs';
+import * as path from 'path';
+import { GrowthEstimator } from '../growthEstimator';
+import { createChecks } from '../../../__tests__/checks';
+
+const goCode = `package stats
+
+func dedupe(items []string) []string {
+    sort.Strings(items)
+    out := []string{}
+    for i := 0; i < len(items); i++ {
+        if i == 0 || items[i] != items[i-1] {
+            out = append(out, items[i])
+        }
+    }
+    return out
+}
+
+func retry(fn func() error) error {
+    for attempt := 0; attempt < MAX_RETRIES; attempt++ {
+        if fn() == nil {
+            return nil
+        }
+    }
+    for i := 0; i < 3; i++ {
+        log.Print(i)
+    }
+    return errFailed
+}
+
+func grid(rows [][]int) int {
+    total := 0
+    for _, row := range rows {
+        for _, cell := range row {
+            total += cell
+        }
+    }
+    return total
+}
+`;
+
+const tsCode = `
+function search(nums: number[], target: number): number {
+    let lo = 0;
+    let hi = nums.length - 1;
+    while (lo <= hi) {
+        const mid = Math.floor((lo + hi) / 2);
+        if (nums[mid] === target) {
+            return mid;
+        } else if (nums[mid] < target) {
+            lo = mid + 1;
+        } else {
+            hi = mid - 1;
+        }
+    }
+    return -1;
+}
+
+function pairs(xs: number[]) {
+    const sorted = [...xs].sort();
+    let count = 0;
+    for (let i = 0; i < xs.length; i++) {
+        for (let j = i + 1; j < xs.length; j++) {
+            if (xs[i] + xs[j] === 0) {
+                count++;
+            }
+        }
+    }
+    return count;
+}
+
+function missing(orders: Order[], ids: string[]) {
+    return orders.filter(order => !ids.includes(order.id));
+}
+`;
+
+const pyCode = `def fib(n):
+    if n < 2:
+        return n
+    return fib(n - 1) + fib(n - 2)
+
+def flatten(grid):
+    return [cell for row in grid for cell in row]
+
+def find(items, target, lo, hi):
+    if lo > hi:
+        return -1
+    mid = (lo + hi) // 2
+    if items[mid] == target:
+        return mid
+    if items[mid] < target:
+        return find(items, target, mid + 1, hi)
+    return find(items, target, lo, mid - 1)
+
+def walk(node, visit):
+    if node is None:
+        return
+    visit(node)
+    walk(node.left, visit)
+    walk(node.right, visit)
+`;
+
+function testGrowthEstimator(): boolean {
+    console.log('\n=== Testing GrowthEstimator ===\n');
+    const { ok, finish } = createChecks();
+
+    const check = (estimates: ReturnType<typeof GrowthEstimator.estimate>, functionName: string, expected: string) => {
+        const estimate = estimates.find(e => e.functionName === functionName);
+        const passed = estimate?.notation === expected;
+        ok(`${functionName}: ${estimate?.summary}${passed ? '' : ` (expected ${expected})`}`, passed);
+        if (!passed) {
+            estimate?.reasons.forEach(reason => console.log(`      line ${reason.line + 1}: ${reason.description}`));
+        }
+    };
+
+    // Test 1: the annotated handler fixture
+    console.log('Test 1: Go handler fixture');
+    const fixture = fs.readFileSync(path.join(__dirname, '../../complexity/__tests__/test-go-example.go'), 'utf8');
+    const handlers = GrowthEstimator.estimate(fixture, 'test-go-example.go');
+    check(handlers, 'whoamiHandler', 'O(1)');
+    check(handlers, 'quadraticHandler', 'O(1)');
+    check(handlers, 'knapsackHandler', 'O(n·C)');
+
+    const knapsack = handlers.find(e => e.functionName === 'knapsackHandler');
+    const legend = knapsack?.symbols.map(s => `${s.symbol}=${s.expression}`).join(',');
+    ok(`knapsack symbols: ${legend}`, legend === 'n=req.Items,C=req.Capacity');
+
+    // Test 2: Go loops, constant bounds and library costs
+    console.log('\nTest 2: Go');
+    const go = GrowthEstimator.estimate(goCode, 'stats.go');
+    check(go, 'dedupe', 'O(n log n)');
+    check(go, 'retry', 'O(1)');
+    check(go, 'grid', 'O(n·m)');
+
+    // Test 3: halving loops, nested counting loops and callbacks
+    console.log('\nTest 3: TypeScript');
+    const ts = GrowthEstimator.estimate(tsCode, 'search.ts');
+    check(ts, 'search', 'O(log n)');
+    check(ts, 'pairs', 'O(n²)');
+    check(ts, 'missing', 'O(n·m)');
+
+    // Test 4: recursion patterns and comprehensions
+    console.log('\nTest 4: Python');
+    const py = GrowthEstimator.estimate(pyCode, 'algos.py');
+    check(py, 'fib', 'O(2^n)');
+    check(py, 'flatten', 'O(n·m)');
+    check(py, 'find', 'O(log n)');
+    check(py, 'walk', 'O(n)');
+
+    return finish();
+}
+
+// Run the test
+process.exit(testGrowthEstimator() ? 0 : 1);
@@ -0,0 +1,1155 @@
+/**
+ * Growth Estimator
+ * Estimates each function's asymptotic complexity (Big-O) from its structure
+ *
+ * Heuristics, not proofs:
+ * - Loops multiply their body by their bound. Collection loops (range, for-of,
+ *   for-in, comprehensions, callbacks like map/forEach) count the collection's
+ *   size; counting loops count to their limit. A counter that is multiplied
+ *   or halved gives a log factor
+ * - Only bounds derived from a parameter or the receiver grow with the input.
+ *   Loops over literals, ALL_CAPS constants and unrelated locals are O(1)
+ * - Known library calls add their cost on their operand's size (sorting is
+ *   n log n, searching and copying linear, binary search log n)
+ * - Self-recursion multiplies the body by the recursion depth: log n when an
+ *   argument is halved, otherwise n. Two or more calls on a decremented
+ *   argument are exponential
+ *
+ * Sequential parts add and only dominant terms are kept, so a loop followed
+ * by a nested loop over the same data is O(n²).
+ */
+
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { Tokenizer } from '../complexity/tokenizer';
+import { FunctionInfo, Language } from '../complexity/types';
+import { GrowthEstimate, GrowthReason, GrowthSymbol, GrowthSymbolKind } from './types';
+
+/**
+ * Code token and its line (0-indexed, relative to the function start)
+ */
+interface Token {
+    text: string;
+    line: number;
+}
+
+/**
+ * Product of factors: 'n' -> power, 'log n' -> power, '2^n' -> 1
+ */
+type Term = Record<string, number>;
+
+/**
+ * Sum of terms
+ */
+type Cost = Term[];
+
+/**
+ * Input-dependent expression a loop or call is bounded by
+ */
+interface Bound {
+    expression: string;
+    kind: GrowthSymbolKind;
+}
+
+type CostShape = 'log' | 'linear' | 'linearithmic';
+
+/**
+ * Cost of a library call on its operand: the first argument,
+ * or the receiver for methods
+ */
+interface LibraryCost {
+    shape: CostShape;
+    operand: 'argument' | 'receiver';
+
+    /** Only applies to a single argument (max(xs), not max(a, b)) */
+    singleArgument?: boolean;
+}
+
+const onArgument = (shape: CostShape, singleArgument = false): LibraryCost => ({ shape, operand: 'argument', singleArgument });
+const onReceiver = (shape: CostShape): LibraryCost => ({ shape, operand: 'receiver' });
+
+/**
+ * Library costs by qualified name; '.name' entries match any method of that name
+ */
+const GO_COSTS: Record<string, LibraryCost> = {
+    'sort.Slice': onArgument('linearithmic'),
+    'sort.SliceStable': onArgument('linearithmic'),
+    'sort.Sort': onArgument('linearithmic'),
+    'sort.Stable': onArgument('linearithmic'),
+    'sort.Strings': onArgument('linearithmic'),
+    'sort.Ints': onArgument('linearithmic'),
+    'sort.Float64s': onArgument('linearithmic'),
+    'slices.Sort': onArgument('linearithmic'),
+    'slices.SortFunc': onArgument('linearithmic'),
+    'slices.SortStableFunc': onArgument('linearithmic'),
+    'sort.Search': onArgument('log'),
+    'sort.SearchInts': onArgument('log'),
+    'sort.SearchStrings': onArgument('log'),
+    'slices.BinarySearch': onArgument('log'),
+    'slices.BinarySearchFunc': onArgument('log'),
+    'slices.Contains': onArgument('linear'),
+    'slices.Index': onArgument('linear'),
+    'slices.Equal': onArgument('linear'),
+    'slices.Reverse': onArgument('linear'),
+    'slices.Clone': onArgument('linear'),
+    'maps.Clone': onArgument('linear'),
+    'strings.Contains': onArgument('linear'),
+    'strings.Index': onArgument('linear'),
+    'strings.Split': onArgument('linear'),
+    'strings.Join': onArgument('linear'),
+    'strings.Fields': onArgument('linear'),
+    'strings.Replace': onArgument('linear'),
+    'strings.ReplaceAll': onArgument('linear'),
+    'bytes.Contains': onArgument('linear'),
+    'bytes.Equal': onArgument('linear'),
+    'bytes.Index': onArgument('linear'),
+    'reflect.DeepEqual': onArgument('linear'),
+    'json.Marshal': onArgument('linear'),
+    'copy': onArgument('linear')
+};
+
+const JS_COSTS: Record<string, LibraryCost> = {
+    '.sort': onReceiver('linearithmic'),
+    '.toSorted': onReceiver('linearithmic'),
+    '.indexOf': onReceiver('linear'),
+    '.lastIndexOf': onReceiver('linear'),
+    '.includes': onReceiver('linear'),
+    '.slice': onReceiver('linear'),
+    '.concat': onReceiver('linear'),
+    '.join': onReceiver('linear'),
+    '.reverse': onReceiver('linear'),
+    '.splice': onReceiver('linear'),
+    '.shift': onReceiver('linear'),
+    '.unshift': onReceiver('linear'),
+    '.split': onReceiver('linear'),
+    'JSON.stringify': onArgument('linear'),
+    'JSON.parse': onArgument('linear'),
+    'Array.from': onArgument('linear'),
+    'Object.keys': onArgument('linear'),
+    'Object.values': onArgument('linear'),
+    'Object.entries': onArgument('linear'),
+    'structuredClone': onArgument('linear'),
+    'Math.max': onArgument('linear', true),
+    'Math.min': onArgument('linear', true)
+};
+
+const PYTHON_COSTS: Record<string, LibraryCost> = {
+    'sorted': onArgument('linearithmic'),
+    '.sort': onReceiver('linearithmic'),
+    'sum': onArgument('linear'),
+    'min': onArgument('linear', true),
+    'max': onArgument('linear', true),
+    'any': onArgument('linear'),
+    'all': onArgument('linear'),
+    'list': onArgument('linear'),
+    'set': onArgument('linear'),
+    'tuple': onArgument('linear'),
+    'dict': onArgument('linear'),
+    'copy.deepcopy': onArgument('linear'),
+    'heapq.heapify': onArgument('linear'),
+    'heapq.heappush': onArgument('log'),
+    'heapq.heappop': onArgument('log'),
+    'bisect.bisect': onArgument('log'),
+    'bisect.bisect_left': onArgument('log'),
+    'bisect.bisect_right': onArgument('log'),
+    '.index': onReceiver('linear'),
+    '.remove': onReceiver('linear'),
+    '.copy': onReceiver('linear'),
+    '.join': onArgument('linear'),
+    '.extend': onArgument('linear')
+};
+
+/** Array methods that run their callback once per element */
+const CALLBACK_LOOPS = new Set([
+    'forEach', 'map', 'filter', 'reduce', 'reduceRight', 'some', 'every',
+    'find', 'findIndex', 'findLast', 'findLastIndex', 'flatMap'
+]);
+
+/** Calls whose result is as large as their first argument */
+const SIZE_WRAPPERS = new Set([
+    'len', 'cap', 'enumerate', 'zip', 'reversed', 'sorted', 'list', 'set', 'tuple', 'iter',
+    'Object.keys', 'Object.values', 'Object.entries', 'Array.from', 'maps.Keys', 'maps.Values'
+]);
+
+/** Methods that iterate their receiver */
+const VIEW_METHODS = new Set(['items', 'keys', 'values', 'entries', 'Len', 'size']);
+
+/** Properties of a collection that hold its size */
+const SIZE_PROPERTIES = new Set(['length', 'size']);
+
+const LITERALS = new Set(['true', 'false', 'True', 'False', 'nil', 'null', 'None', 'undefined']);
+const NULLISH = new Set(['nil', 'null', 'None', 'undefined']);
+const KEYWORDS = new Set([
+    'func', 'function', 'def', 'async', 'await', 'static', 'public', 'private', 'protected',
+    'readonly', 'export', 'default', 'return', 'new', 'const', 'let', 'var', 'for', 'if'
+]);
+const DEFINITION_KEYWORDS = new Set(['func', 'function', 'def']);
+
+const COMPARISONS = new Set(['<', '<=', '>', '>=', '!=', '!==']);
+const LOGICAL = new Set(['&&', '||', 'and', 'or']);
+const ARITHMETIC = new Set(['+', '-', '*', '/', '%']);
+const PREFIXES = new Set(['...', '!', 'not', '&', '*', '-']);
+const SCALING_ASSIGNMENTS = new Set(['*=', '/=', '<<=', '>>=']);
+
+// Python blocks become bracket-like tokens so one walker handles every language
+const INDENT = '<indent>';
+const DEDENT = '<dedent>';
+const NEWLINE = '<newline>';
+
+const PAIRS: Record<string, string> = { '(': ')', '[': ']', '{': '}', [INDENT]: DEDENT };
+const OPENERS = new Set(['(', '[', '{']);
+const CLOSERS = new Set([')', ']', '}']);
+
+const SIZE_SYMBOLS = ['n', 'm', 'k', 'p', 'q', 'r', 's'];
+const SUPERSCRIPTS = '⁰¹²³⁴⁵⁶⁷⁸⁹';
+
+export class GrowthEstimator {
+    /**
+     * Estimate the complexity of every function in a file
+     *
+     * @param sourceCode - Full file contents
+     * @param filename - File name for language detection
+     * @returns Estimates in source order
+     */
+    static estimate(sourceCode: string, filename: string): GrowthEstimate[] {
+        const analyzer = ComplexityAnalyzer.forFile(filename);
+        const language = analyzer.getLanguage();
+        const lines = sourceCode.split('\n');
+
+        return analyzer.analyze(sourceCode).functions
+            .filter(func => func.name !== '*global*')
+            .map(func => new FunctionWalker(func, lines.slice(func.startLine, func.endLine + 1), language).estimate());
+    }
+}
+
+/**
+ * Walks one function's tokens, building its cost
+ */
+class FunctionWalker {
+    private func: FunctionInfo;
+    private language: Language;
+    private tokens: Token[];
+    private bodyStart: number;
+    private costs: Record<string, LibraryCost>;
+    private selfNames = new Set(['this', 'self']);
+    private tainted: Set<string>;
+    private aliases = new Map<string, string[]>();
+    private symbols: GrowthSymbol[] = [];
+    private reasons: GrowthReason[] = [];
+    private selfCalls: { line: number; args: string[][]; returned: boolean }[] = [];
+    private maxLoopDepth = 0;
+
+    constructor(func: FunctionInfo, lines: string[], language: Language) {
+        this.func = func;
+        this.language = language;
+        this.tokens = language === Language.Python ? pythonTokens(lines) : braceTokens(lines);
+        this.costs = language === Language.Go ? GO_COSTS : language === Language.Python ? PYTHON_COSTS : JS_COSTS;
+
+        this.bodyStart = this.findBodyStart();
+        const signature = this.tokens.slice(0, this.bodyStart).map(t => t.text);
+        const parameters = signature.filter(t => isIdentifier(t) && !KEYWORDS.has(t) && t !== func.name);
+
+        // Go receivers: func (s *Server) name(...)
+        if (language === Language.Go && signature[1] === '(' && isIdentifier(signature[2] || '')) {
+            this.selfNames.add(signature[2]);
+        }
+
+        const code = lines.map(line => stripStrings(language === Language.Python ? stripPythonComment(line) : line.replace(/\/\/.*$/, '')));
+        this.tainted = taint(code, [...parameters, ...this.selfNames]);
+        this.collectAliases(code);
+    }
+
+    estimate(): GrowthEstimate {
+        const cost = this.recursion(this.block(this.bodyStart, this.tokens.length, 0));
+        const order = this.symbols.map(s => s.symbol);
+        const terms = [...cost].sort((a, b) => degreeOf(b) - degreeOf(a));
+        const notation = `O(${terms.map(term => formatTerm(term, order)).join(' + ')})`;
+
+        const used = new Set(terms.flatMap(term => Object.keys(term).map(symbolOf)));
+        const symbols = this.symbols.filter(s => used.has(s.symbol));
+        const degree = Math.max(0, ...terms.map(degreeOf));
+
+        return {
+            functionName: this.func.name,
+            startLine: this.func.startLine,
+            endLine: this.func.endLine,
+            notation,
+            degree,
+            symbols,
+            reasons: this.reasons.map(reason => ({ ...reason, line: reason.line + this.func.startLine })),
+            summary: this.summarize(notation, degree, symbols)
+        };
+    }
+
+    private summarize(notation: string, degree: number, symbols: GrowthSymbol[]): string {
+        if (symbols.length === 0) {
+            return `${notation}: no input-dependent loops`;
+        }
+
+        const shape = degree === Infinity ? 'exponential recursion'
+            : this.maxLoopDepth > 1 ? 'nested loop'
+            : this.selfCalls.length > 0 ? 'recursion'
+            : this.maxLoopDepth === 1 ? 'loop'
+            : 'library calls';
+        const legend = symbols
+            .filter(s => s.symbol !== s.expression)
+            .map(s => `${s.symbol} = ${s.kind === 'size' ? 'size of ' : ''}${s.expression}`)
+            .join(', ');
+
+        return `${notation} ${shape} over input-sized data${legend ? ` (${legend})` : ''}`;
+    }
+
+    /**
+     * Index of the first body token, after the signature
+     */
+    private findBodyStart(): number {
+        let depth = 0;
+        for (let i = 0; i < this.tokens.length; i++) {
+            const text = this.tokens[i].text;
+            if (text === '(' || text === '[') {
+                depth++;
+            } else if (text === ')' || text === ']') {
+                depth--;
+            } else if (depth === 0) {
+                if (this.language === Language.Python ? text === ':' : text === '=>') {
+                    return i + 1;
+                }
+                if (text === '{' && this.language !== Language.Python) {
+                    return i;
+                }
+            }
+        }
+        return 0;
+    }
+
+    /**
+     * First assignment of each local, so bounds like 'i < n' can be traced
+     * back to 'n := len(items)'
+     */
+    private collectAliases(code: string[]): void {
+        for (const line of code) {
+            const match = /^\s*(?:(?:var|let|const)\s+)?([A-Za-z_]\w*)\s*(?::\s*[^=]+)?(?::=|=)(?![=>])(.*)$/.exec(line);
+            if (!match || KEYWORDS.has(match[1]) || this.aliases.has(match[1])) {
+                continue;
+            }
+
+            const value = codeTokens(match[2]).filter(t => t !== ';');
+            if (!value.includes(match[1])) {
+                this.aliases.set(match[1], value);
+            }
+        }
+    }
+
+    /**
+     * Cost of a token range: the sum of its loops and calls
+     */
+    private block(start: number, end: number, depth: number): Cost {
+        let cost: Cost = [{}];
+        let brackets = 0;
+        let i = start;
+
+        while (i < end) {
+            const text = this.tokens[i].text;
+
+            if (this.isLoop(text)) {
+                const loop = this.language === Language.Python && brackets > 0
+                    ? this.comprehension(i, end, depth)
+                    : this.loop(i, end, depth);
+                cost = add(cost, loop.cost);
+                i = loop.next;
+                continue;
+            }
+
+            if (OPENERS.has(text)) {
+                brackets++;
+            } else if (CLOSERS.has(text)) {
+                brackets--;
+            } else if (isIdentifier(text) && i + 1 < end && this.text(i + 1) === '(') {
+                const call = this.call(i, end, depth);
+                cost = add(cost, call.cost);
+                i = call.next;
+                continue;
+            }
+
+            i++;
+        }
+
+        return cost;
+    }
+
+    private isLoop(text: string): boolean {
+        switch (this.language) {
+            case Language.Go:
+                return text === 'for';
+            case Language.Python:
+                return text === 'for' || text === 'while';
+            default:
+                return text === 'for' || text === 'while' || text === 'do';
+        }
+    }
+
+    private loop(i: number, end: number, depth: number): { cost: Cost; next: number } {
+        const keyword = this.text(i) === 'do' ? 'while' : this.text(i);
+        let header: string[];
+        let body: { start: number; end: number; next: number };
+        let next: number;
+
+        if (this.text(i) === 'do') {
+            body = this.statement(i + 1, end);
+            header = [];
+            next = body.next;
+            if (this.text(next) === 'while' && this.text(next + 1) === '(') {
+                const close = this.matching(next + 1, end);
+                header = this.texts(next + 2, close);
+                next = close + 1;
+            }
+        } else {
+            let headerStart = i + 1;
+            let headerEnd: number;
+            let bodyStart: number;
+
+            if (this.language === Language.Python) {
+                headerEnd = this.find(headerStart, end, ':');
+                bodyStart = headerEnd + 1;
+            } else if (this.language === Language.Go) {
+                headerEnd = this.find(headerStart, end, '{');
+                bodyStart = headerEnd;
+            } else {
+                if (this.text(headerStart) === 'await') {
+                    headerStart++;
+                }
+                headerEnd = this.text(headerStart) === '(' ? this.matching(headerStart, end) : headerStart;
+                headerStart++;
+                bodyStart = headerEnd + 1;
+            }
+
+            header = this.texts(headerStart, headerEnd);
+            body = this.statement(bodyStart, end);
+            next = body.next;
+        }
+
+        const line = this.tokens[i].line;
+        const bound = keyword === 'for'
+            ? this.forBound(header, body.start, body.end)
+            : this.conditionBound(header, body.start, body.end);
+
+        if (!bound) {
+            return { cost: this.block(body.start, body.end, depth), next };
+        }
+
+        const factor = this.factor(bound, bound.logarithmic);
+        this.maxLoopDepth = Math.max(this.maxLoopDepth, depth + 1);
+        this.note(line, `${depth > 0 ? 'nested ' : ''}${bound.logarithmic ? 'halving ' : ''}loop ${bound.kind === 'size' ? 'over' : 'to'} ${bound.expression} (${formatTerm(factor, [])})`);
+
+        return { cost: multiply(this.block(body.start, body.end, depth + 1), factor), next };
+    }
+
+    /**
+     * Python comprehension: each 'for' clause multiplies
+     */
+    private comprehension(i: number, end: number, depth: number): { cost: Cost; next: number } {
+        let stop = end;
+        let brackets = 0;
+        for (let j = i; j < end; j++) {
+            const text = this.text(j);
+            if (OPENERS.has(text)) {
+                brackets++;
+            } else if (CLOSERS.has(text)) {
+                if (brackets === 0) {
+                    stop = j;
+                    break;
+                }
+                brackets--;
+            }
+        }
+
+        const clauses = splitTopLevel(this.texts(i, stop), new Set(['for', 'if']));
+        let factor: Term = {};
+        let loops = 0;
+
+        for (const clause of clauses) {
+            const index = clause.indexOf('in');
+            const bound = index >= 0 ? this.normalize(clause.slice(index + 1), 'size') : null;
+            if (!bound) {
+                continue;
+            }
+
+            const clauseFactor = this.factor(bound, false);
+            factor = multiply([factor], clauseFactor)[0];
+            loops++;
+            this.note(this.tokens[i].line, `${depth + loops > 1 ? 'nested ' : ''}comprehension over ${bound.expression} (${formatTerm(clauseFactor, [])})`);
+        }
+
+        this.maxLoopDepth = Math.max(this.maxLoopDepth, depth + loops);
+        return { cost: [factor], next: stop };
+    }
+
+    /**
+     * Costs of a call: callback loops, library calls and self-recursion
+     */
+    private call(i: number, end: number, depth: number): { cost: Cost; next: number } {
+        const name = this.text(i);
+        const line = this.tokens[i].line;
+
+        // Walk back over a dotted receiver
+        let start = i;
+        while (this.text(start - 1) === '.' && isIdentifier(this.text(start - 2))) {
+            start -= 2;
+        }
+        const isMethod = this.text(i - 1) === '.';
+        const receiver = start < i ? this.texts(start, i - 1) : [];
+        const qualified = this.texts(start, i + 1).join('');
+
+        const close = this.matching(i + 1, end);
+        const args = splitTopLevel(this.texts(i + 2, close), new Set([',']));
+
+        if (name === this.func.name && !DEFINITION_KEYWORDS.has(this.text(start - 1)) &&
+            (!isMethod || this.selfNames.has(receiver[0]))) {
+            this.selfCalls.push({ line, args, returned: this.text(start - 1) === 'return' });
+            return { cost: [{}], next: i + 1 };
+        }
+
+        if (isMethod && CALLBACK_LOOPS.has(name) &&
+            (this.language === Language.TypeScript || this.language === Language.JavaScript)) {
+            const bound = this.normalize(receiver, 'size');
+            if (!bound) {
+                return { cost: this.block(i + 2, close, depth), next: close + 1 };
+            }
+
+            const factor = this.factor(bound, false);
+            this.maxLoopDepth = Math.max(this.maxLoopDepth, depth + 1);
+            this.note(line, `${depth > 0 ? 'nested ' : ''}${name} over ${bound.expression} (${formatTerm(factor, [])})`);
+            return { cost: multiply(this.block(i + 2, close, depth + 1), factor), next: close + 1 };
+        }
+
+        const library = this.costs[qualified] || (isMethod ? this.costs[`.${name}`] : undefined);
+        if (library && !(library.singleArgument && args.length > 1)) {
+            const bound = this.normalize(library.operand === 'receiver' ? receiver : args[0] || [], 'size');
+            if (bound) {
+                const symbol = this.symbolFor(bound);
+                const term: Term = library.shape === 'log' ? { [`log ${symbol}`]: 1 }
+                    : library.shape === 'linear' ? { [symbol]: 1 }
+                    : { [symbol]: 1, [`log ${symbol}`]: 1 };
+                this.note(line, `${qualified} on ${bound.expression} (${formatTerm(term, [])})`);
+                return { cost: [term], next: i + 1 };
+            }
+        }
+
+        return { cost: [{}], next: i + 1 };
+    }
+
+    /**
+     * Apply self-recursion to the function's non-recursive cost
+     */
+    private recursion(body: Cost): Cost {
+        if (this.selfCalls.length === 0) {
+            return body;
+        }
+
+        // 'return f(a)' in one branch and 'return f(b)' in another is one call per invocation
+        const returned = this.selfCalls.filter(call => call.returned).length;
+        const calls = this.selfCalls.length - returned + (returned > 0 ? 1 : 0);
+        const args = this.selfCalls.flatMap(call => call.args.flat());
+        const halving = args.some((t, i) => t === '/' || (t === '>' && args[i + 1] === '>') || /^(mid|middle|half)/i.test(t));
+        const decrementing = args.includes('-');
+
+        const kind = decrementing && !halving ? 'magnitude' : 'size';
+        const bound = this.normalize(this.selfCalls[0].args[0] || [], kind);
+        const root = bound ? bound.expression.split(/[.[]/)[0] : 'recursion depth';
+        const symbol = this.symbolFor({ expression: root, kind });
+        const line = this.selfCalls[0].line;
+        const plural = calls > 1 ? `${calls} recursive calls` : 'recursive call';
+
+        if (halving && calls === 1) {
+            this.note(line, `${plural} halving ${root} (log ${symbol})`);
+            return multiply(body, { [`log ${symbol}`]: 1 });
+        }
+        if (halving) {
+            this.note(line, `${plural} splitting ${root} (divide and conquer)`);
+            return add([{ [symbol]: 1 }], multiply(body, { [`log ${symbol}`]: 1 }));
+        }
+        if (decrementing && calls > 1) {
+            this.note(line, `${plural} on ${root} (${calls}^${symbol})`);
+            return multiply(body, { [`${calls}^${symbol}`]: 1 });
+        }
+
+        this.note(line, `${plural} on ${root} (${symbol})`);
+        return multiply(body, { [symbol]: 1 });
+    }
+
+    /**
+     * Bound of a 'for' header: three-clause, range/of/in, or a bare condition
+     */
+    private forBound(header: string[], bodyStart: number, bodyEnd: number): (Bound & { logarithmic: boolean }) | null {
+        const clauses = splitTopLevel(header, new Set([';']));
+        if (clauses.length === 3) {
+            return this.countingBound(clauses[0], clauses[1], clauses[2]);
+        }
+
+        const keywords = this.language === Language.Go ? ['range'] : ['in', 'of'];
+        const index = header.findIndex(t => keywords.includes(t));
+        if (index >= 0) {
+            const bound = this.normalize(header.slice(index + 1), 'size');
+            return bound ? { ...bound, logarithmic: false } : null;
+        }
+
+        // Go's 'for cond {}' is a while loop, 'for {}' is unbounded
+        return header.length > 0 ? this.conditionBound(header, bodyStart, bodyEnd) : null;
+    }
+
+    private countingBound(init: string[], condition: string[], update: string[]): (Bound & { logarithmic: boolean }) | null {
+        const counter = init.find(t => isIdentifier(t) && !KEYWORDS.has(t));
+        const assignment = init.findIndex(t => t === '=' || t === ':=');
+        const start = assignment >= 0 ? init.slice(assignment + 1) : [];
+
+        const sides = comparisonSides(condition);
+        const limit = !sides ? [] : counter && sides[1].includes(counter) ? sides[0] : sides[1];
+
+        const decreasing = update.includes('--') || update.includes('-=');
+        const logarithmic = update.some(t => SCALING_ASSIGNMENTS.has(t)) ||
+            (update.includes('=') && (update.includes('*') || update.includes('/')));
+
+        // Counting down starts from the large end
+        for (const candidate of decreasing ? [start, limit] : [limit, start]) {
+            const bound = this.normalize(candidate, 'magnitude');
+            if (bound) {
+                return { ...bound, logarithmic };
+            }
+        }
+        return null;
+    }
+
+    private conditionBound(condition: string[], bodyStart: number, bodyEnd: number): (Bound & { logarithmic: boolean }) | null {
+        const logarithmic = this.halves(condition, bodyStart, bodyEnd);
+        const sides = comparisonSides(condition);
+
+        let bound: Bound | null = null;
+        if (!sides) {
+            // 'while queue' drains a collection
+            bound = this.normalize(condition, 'size');
+        } else if (sides.some(side => side.length === 1 && NULLISH.has(side[0]))) {
+            // 'node != nil' walks a linked structure
+            bound = this.normalize(NULLISH.has(sides[0][0]) ? sides[1] : sides[0], 'size');
+        } else {
+            bound = this.normalize(sides[1], 'magnitude') || this.normalize(sides[0], 'magnitude');
+        }
+
+        return bound ? { ...bound, logarithmic } : null;
+    }
+
+    /**
+     * Whether a loop body halves or doubles something its condition tests
+     */
+    private halves(condition: string[], start: number, end: number): boolean {
+        const tested = new Set(condition.filter(isIdentifier));
+        const byLine = new Map<number, string[]>();
+        for (let i = start; i < end; i++) {
+            const { text, line } = this.tokens[i];
+            byLine.set(line, [...(byLine.get(line) || []), text]);
+        }
+
+        for (const texts of byLine.values()) {
+            const scales = texts.some((t, i) =>
+                SCALING_ASSIGNMENTS.has(t) ||
+                ((t === '/' || t === '*') && texts[i + 1] === '2') ||
+                (t === '>' && texts[i + 1] === '>'));
+            if (scales && texts.some(t => tested.has(t))) {
+                return true;
+            }
+        }
+        return false;
+    }
+
+    /**
+     * Reduce an expression to the input-dependent quantity it grows with,
+     * or null when it is constant
+     */
+    private normalize(tokens: string[], kind: GrowthSymbolKind, depth = 0): Bound | null {
+        let expression = tokens.filter(t => t !== NEWLINE);
+        if (expression.includes('for')) {
+            // Generator expressions are costed as comprehensions
+            return null;
+        }
+        while (expression[0] === '(' && closeIndex(expression, 0) === expression.length - 1) {
+            expression = expression.slice(1, -1);
+        }
+
+        for (const operand of splitTopLevel(expression, ARITHMETIC)) {
+            const bound = this.operand(operand, kind, depth);
+            if (bound) {
+                return bound;
+            }
+        }
+        return null;
+    }
+
+    private operand(tokens: string[], kind: GrowthSymbolKind, depth: number): Bound | null {
+        let p = 0;
+        while (p < tokens.length && PREFIXES.has(tokens[p])) {
+            p++;
+        }
+
+        const first = tokens[p];
+        if (!first || !isIdentifier(first) || /^\d/.test(first) || LITERALS.has(first)) {
+            return null;
+        }
+
+        const start = p;
+        const chain = [first];
+        let name = first;
+        let lastDot = -1;
+        p++;
+
+        while (p < tokens.length) {
+            if (tokens[p] === '.' && isIdentifier(tokens[p + 1] || '')) {
+                lastDot = p;
+                chain.push(tokens[p + 1]);
+                name += `.${tokens[p + 1]}`;
+                p += 2;
+            } else if (tokens[p] === '[') {
+                name += '[]';
+                p = closeIndex(tokens, p) + 1;
+            } else if (tokens[p] === '(') {
+                const args = splitTopLevel(tokens.slice(p + 1, closeIndex(tokens, p)), new Set([',']));
+                const receiver = lastDot >= 0 ? tokens.slice(start, lastDot) : [];
+                return this.callResult(name, chain[chain.length - 1], receiver, args, kind, depth);
+            } else {
+                break;
+            }
+        }
+
+        if (chain.length > 1 && SIZE_PROPERTIES.has(chain[chain.length - 1])) {
+            chain.pop();
+            name = name.slice(0, name.lastIndexOf('.'));
+            kind = 'size';
+        }
+
+        // Constants don't grow with the input
+        const last = chain[chain.length - 1];
+        if (last.length > 1 && /^[A-Z][A-Z0-9_]*$/.test(last)) {
+            return null;
+        }
+
+        if (name === first && depth < 5) {
+            const alias = this.aliases.get(first);
+            const resolved = alias && this.normalize(alias, kind, depth + 1);
+            if (resolved) {
+                return resolved;
+            }
+        }
+
+        return this.tainted.has(first) ? { expression: name, kind } : null;
+    }
+
+    /**
+     * Quantity a call expression grows with
+     */
+    private callResult(name: string, method: string, receiver: string[], args: string[][], kind: GrowthSymbolKind, depth: number): Bound | null {
+        if (name === 'range') {
+            return this.normalize(args.length > 1 ? args[1] : args[0] || [], 'magnitude', depth);
+        }
+        if (SIZE_WRAPPERS.has(name)) {
+            return this.normalize(args[0] || [], 'size', depth);
+        }
+        if (receiver.length > 0 && VIEW_METHODS.has(method)) {
+            return this.operand(receiver, 'size', depth);
+        }
+
+        // Unknown call: assume its result is as large as its input
+        if (args.length > 0 && args[0].length > 0) {
+            return this.normalize(args[0], kind, depth);
+        }
+        return receiver.length > 0 ? this.operand(receiver, kind, depth) : null;
+    }
+
+    /**
+     * Factor contributed by a bound, registering its symbol
+     */
+    private factor(bound: Bound, logarithmic: boolean): Term {
+        const symbol = this.symbolFor(bound);
+        return logarithmic ? { [`log ${symbol}`]: 1 } : { [symbol]: 1 };
+    }
+
+    /**
+     * Symbol for an expression: n, m, k... for sizes, the initial of the
+     * last name part for magnitudes (req.Capacity -> C)
+     */
+    private symbolFor(bound: Bound): string {
+        const existing = this.symbols.find(s => s.expression === bound.expression);
+        if (existing) {
+            return existing.symbol;
+        }
+
+        const used = new Set(this.symbols.map(s => s.symbol));
+        let symbol: string | undefined;
+        if (bound.kind === 'magnitude') {
+            const last = bound.expression.split('.').pop()!.replace(/\[\]/g, '');
+            const candidate = last.length === 1 ? last : last[0].toUpperCase();
+            if (/^[A-Za-z]$/.test(candidate) && candidate !== 'O' && !used.has(candidate)) {
+                symbol = candidate;
+            }
+        }
+        symbol = symbol || SIZE_SYMBOLS.find(s => !used.has(s)) || `n${this.symbols.length}`;
+
+        this.symbols.push({ symbol, expression: bound.expression, kind: bound.kind });
+        return symbol;
+    }
+
+    private note(line: number, description: string): void {
+        this.reasons.push({ line, description });
+    }
+
+    /**
+     * Extent of a loop body: a block, or a single statement
+     */
+    private statement(start: number, end: number): { start: number; end: number; next: number } {
+        while (this.text(start) === NEWLINE) {
+            start++;
+        }
+
+        if (this.text(start) === '{' || this.text(start) === INDENT) {
+            const close = this.matching(start, end);
+            return { start: start + 1, end: close, next: close + 1 };
+        }
+
+        let depth = 0;
+        for (let i = start; i < end; i++) {
+            const text = this.text(i);
+            if (OPENERS.has(text) || text === INDENT) {
+                depth++;
+            } else if (CLOSERS.has(text) || text === DEDENT) {
+                if (depth === 0) {
+                    return { start, end: i, next: i };
+                }
+                depth--;
+            } else if (depth === 0 && (text === ';' || text === NEWLINE)) {
+                return { start, end: i, next: i + 1 };
+            }
+        }
+        return { start, end, next: end };
+    }
+
+    /**
+     * First token at bracket depth 0 with the given text, or end
+     */
+    private find(start: number, end: number, target: string): number {
+        let depth = 0;
+        for (let i = start; i < end; i++) {
+            const text = this.text(i);
+            if (depth === 0 && text === target) {
+                return i;
+            }
+            if (text === '(' || text === '[') {
+                depth++;
+            } else if (text === ')' || text === ']') {
+                depth--;
+            }
+        }
+        return end;
+    }
+
+    /**
+     * Index of the token closing the bracket at open, or end
+     */
+    private matching(open: number, end: number): number {
+        const opener = this.text(open);
+        const closer = PAIRS[opener];
+        let depth = 0;
+        for (let i = open; i < end; i++) {
+            const text = this.text(i);
+            if (text === opener) {
+                depth++;
+            } else if (text === closer && --depth === 0) {
+                return i;
+            }
+        }
+        return end;
+    }
+
+    private text(index: number): string {
+        return this.tokens[index]?.text ?? '';
+    }
+
+    private texts(start: number, end: number): string[] {
+        return this.tokens.slice(start, end).map(t => t.text);
+    }
+}
+
+/**
+ * Tokens of a brace language, with their lines
+ */
+function braceTokens(lines: string[]): Token[] {
+    const tokens: Token[] = [];
+    let line = 0;
+    for (const text of Tokenizer.generateTokens(lines.join('\n'))) {
+        if (Tokenizer.isCodeToken(text)) {
+            tokens.push({ text, line });
+        }
+        line += text.split('\n').length - 1;
+    }
+    return tokens;
+}
+
+/**
+ * Python tokens, with indentation turned into INDENT/DEDENT and
+ * logical line ends marked with NEWLINE
+ */
+function pythonTokens(lines: string[]): Token[] {
+    // Blank out docstrings, keeping their line breaks
+    const source = lines.join('\n').replace(/("""|''')[\s\S]*?\1/g, s => '""' + '\n'.repeat(s.split('\n').length - 1));
+    const tokens: Token[] = [];
+    const indents: number[] = [];
+    let brackets = 0;
+
+    source.split('\n').forEach((raw, line) => {
+        // '//' is floor division, not a comment
+        const text = stripPythonComment(raw).replace(/\/\//g, '/');
+        if (!text.trim()) {
+            return;
+        }
+
+        if (brackets === 0) {
+            const indent = text.length - text.trimStart().length;
+            while (indents.length > 1 && indent < indents[indents.length - 1]) {
+                indents.pop();
+                tokens.push({ text: DEDENT, line });
+            }
+            if (indents.length === 0 || indent > indents[indents.length - 1]) {
+                if (indents.length > 0) {
+                    tokens.push({ text: INDENT, line });
+                }
+                indents.push(indent);
+            }
+        }
+
+        for (const token of codeTokens(text)) {
+            tokens.push({ text: token, line });
+            if (OPENERS.has(token)) {
+                brackets++;
+            } else if (CLOSERS.has(token)) {
+                brackets--;
+            }
+        }
+
+        if (brackets <= 0) {
+            brackets = 0;
+            tokens.push({ text: NEWLINE, line });
+        }
+    });
+
+    while (indents.length > 1) {
+        indents.pop();
+        tokens.push({ text: DEDENT, line: lines.length - 1 });
+    }
+    return tokens;
+}
+
+function codeTokens(source: string): string[] {
+    return Tokenizer.filterCodeTokens(Tokenizer.generateTokens(source));
+}
+
+function stripPythonComment(line: string): string {
+    let quote: string | null = null;
+    for (let i = 0; i < line.length; i++) {
+        const c = line[i];
+        if (quote) {
+            if (c === '\\') {
+                i++;
+            } else if (c === quote) {
+                quote = null;
+            }
+        } else if (c === '"' || c === "'") {
+            quote = c;
+        } else if (c === '#') {
+            return line.slice(0, i);
+        }
+    }
+    return line;
+}
+
+function stripStrings(line: string): string {
+    return line.replace(/"(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|`[^`]*`/g, '""');
+}
+
+/**
+ * Identifiers that carry input: parameters, the receiver, and anything
+ * assigned from them
+ */
+function taint(code: string[], roots: string[]): Set<string> {
+    const tainted = new Set(roots);
+
+    // Two passes catch values assigned before the loop that feeds them
+    for (let pass = 0; pass < 2; pass++) {
+        for (const line of code) {
+            const identifiers = line.match(/[A-Za-z_]\w*/g) || [];
+            if (identifiers.some(id => tainted.has(id))) {
+                assignmentTargets(line).forEach(target => tainted.add(target));
+            }
+        }
+    }
+    return tainted;
+}
+
+function assignmentTargets(line: string): string[] {
+    const targets: string[] = [];
+    const firstIdentifier = (part: string) => (part.match(/[A-Za-z_]\w*/g) || []).find(id => !KEYWORDS.has(id));
+
+    const assignment = /^\s*([^=<>!]*?)(?::=|[+\-*/%|&^]?=)(?![=>])/.exec(line);
+    if (assignment) {
+        for (const part of assignment[1].split(',')) {
+            const target = firstIdentifier(part);
+            if (target) {
+                targets.push(target);
+            }
+        }
+    }
+
+    const iteration = /\bfor\s*\(?\s*(?:const|let|var)?\s*([\w\s,[\]{}()]*?)\s+(?:in|of)\b/.exec(line);
+    if (iteration) {
+        targets.push(...(iteration[1].match(/[A-Za-z_]\w*/g) || []));
+    }
+
+    // Decode(&req), items.push(x), out = append(out, x)
+    for (const match of line.matchAll(/(?<![&\w)\]])&([A-Za-z_]\w*)/g)) {
+        targets.push(match[1]);
+    }
+    for (const match of line.matchAll(/([A-Za-z_]\w*)\s*\.\s*(?:push|append|add|extend|insert|unshift|set|put)\s*\(/g)) {
+        targets.push(match[1]);
+    }
+
+    return targets;
+}
+
+/**
+ * Left and right operands of the first comparison in a condition
+ */
+function comparisonSides(condition: string[]): [string[], string[]] | null {
+    for (const part of splitTopLevel(condition, LOGICAL)) {
+        const index = part.findIndex(t => COMPARISONS.has(t));
+        if (index >= 0) {
+            return [part.slice(0, index), part.slice(index + 1)];
+        }
+    }
+    return null;
+}
+
+function splitTopLevel(tokens: string[], separators: Set<string>): string[][] {
+    const parts: string[][] = [[]];
+    let depth = 0;
+    for (const token of tokens) {
+        if (OPENERS.has(token)) {
+            depth++;
+        } else if (CLOSERS.has(token)) {
+            depth--;
+        } else if (depth === 0 && separators.has(token)) {
+            parts.push([]);
+            continue;
+        }
+        parts[parts.length - 1].push(token);
+    }
+    return parts;
+}
+
+function closeIndex(tokens: string[], open: number): number {
+    let depth = 0;
+    for (let i = open; i < tokens.length; i++) {
+        if (OPENERS.has(tokens[i])) {
+            depth++;
+        } else if (CLOSERS.has(tokens[i]) && --depth === 0) {
+            return i;
+        }
+    }
+    return tokens.length - 1;
+}
+
+function isIdentifier(token: string): boolean {
+    return /^[A-Za-z_]\w*$/.test(token);
+}
+
+// --- Cost arithmetic ---
+
+function multiply(cost: Cost, factor: Term): Cost {
+    return cost.map(term => {
+        const product = { ...term };
+        for (const [key, power] of Object.entries(factor)) {
+            product[key] = (product[key] || 0) + power;
+        }
+        return product;
+    });
+}
+
+function add(a: Cost, b: Cost): Cost {
+    const cost = [...a, ...b];
+    return cost.filter((term, i) => !cost.some((other, j) =>
+        j !== i && dominates(other, term) && (!dominates(term, other) || j < i)));
+}
+
+function symbolOf(key: string): string {
+    return key.startsWith('log ') ? key.slice(4) : key.includes('^') ? key.split('^')[1] : key;
+}
+
+/**
+ * Growth in one symbol as [exponential base, power, log power]
+ */
+function growthIn(term: Term, symbol: string): [number, number, number] {
+    let base = 0;
+    for (const [key, power] of Object.entries(term)) {
+        if (key.includes('^') && symbolOf(key) === symbol) {
+            base = Math.max(base, Number(key.split('^')[0]) * power);
+        }
+    }
+    return [base, term[symbol] || 0, term[`log ${symbol}`] || 0];
+}
+
+/**
+ * Whether a grows at least as fast as b in every symbol
+ */
+function dominates(a: Term, b: Term): boolean {
+    return Object.keys(b).map(symbolOf).every(symbol => {
+        const ga = growthIn(a, symbol);
+        const gb = growthIn(b, symbol);
+        for (let i = 0; i < 3; i++) {
+            if (ga[i] !== gb[i]) {
+                return ga[i] > gb[i];
+            }
+        }
+        return true;
+    });
+}
+
+function degreeOf(term: Term): number {
+    if (Object.keys(term).some(key => key.includes('^'))) {
+        return Infinity;
+    }
+    return Object.entries(term)
+        .filter(([key]) => !key.startsWith('log '))
+        .reduce((sum, [, power]) => sum + power, 0);
+}
+
+function superscript(power: number): string {
+    return String(power).split('').map(d => SUPERSCRIPTS[Number(d)]).join('');
+}
+
+/**
+ * Format a term, ordering symbols by first appearance
+ */
+function formatTerm(term: Term, order: string[]): string {
+    const symbols = [...new Set([...order, ...Object.keys(term).map(symbolOf)])]
+        .filter(symbol => Object.keys(term).some(key => symbolOf(key) === symbol));
+
+    const factors: string[] = [];
+    const logs: string[] = [];
+    for (const symbol of symbols) {
+        const [base, power, logPower] = growthIn(term, symbol);
+        if (base > 0) {
+            factors.push(`${base}^${symbol}`);
+        }
+        if (power > 0) {
+            factors.push(power > 1 ? `${symbol}${superscript(power)}` : symbol);
+        }
+        if (logPower > 0) {
+            logs.push(logPower > 1 ? `log${superscript(logPower)} ${symbol}` : `log ${symbol}`);
+        }
+    }
+
+    return [factors.join('·'), ...logs].filter(Boolean).join(' ') || '1';
+}
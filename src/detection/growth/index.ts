@@ -0,0 +1,36 @@
+/**
+ * Growth Module
+ *
+ * Heuristic Big-O estimates from loop nesting over input-dependent bounds,
+ * recursion patterns and known library costs
+ *
+ * @module growth
+ */
+
+export { GrowthEstimator } from './growthEstimator';
+
+export type {
+    GrowthEstimate,
+    GrowthSymbol,
+    GrowthSymbolKind,
+    GrowthReason
+} from './types';
+
+/**
+ * Quick API: Estimate the complexity of every function in a file
+ *
+ * @param code - Source code to analyze
+ * @param filename - Filename for language detection
+ * @returns Per-function estimates in source order
+ *
+ * @example
+ * ```typescript
+ * for (const estimate of estimateGrowth(code, 'handlers.go')) {
+ *   console.log(`${estimate.functionName}: ${estimate.summary}`);
+ * }
+ * ```
+ */
+export function estimateGrowth(code: string, filename: string) {
+    const { GrowthEstimator } = require('./growthEstimator');
+    return GrowthEstimator.estimate(code, filename);
+}
@@ -0,0 +1,61 @@
+/**
+ * Type definitions for algorithmic complexity estimation
+ */
+
+/**
+ * What a symbol measures: the size of a collection, or a plain
+ * number a loop counts to
+ */
+export type GrowthSymbolKind = 'size' | 'magnitude';
+
+/**
+ * An input-dependent quantity used in a Big-O expression
+ */
+export interface GrowthSymbol {
+    /** Symbol as written in the notation (e.g. "n", "C") */
+    symbol: string;
+
+    /** Source expression it stands for (e.g. "req.Items") */
+    expression: string;
+
+    kind: GrowthSymbolKind;
+}
+
+/**
+ * A construct that contributed to the estimate
+ */
+export interface GrowthReason {
+    /** Line of the construct (0-indexed) */
+    line: number;
+
+    /** Human-readable description */
+    description: string;
+}
+
+/**
+ * Estimated asymptotic complexity of a function
+ */
+export interface GrowthEstimate {
+    functionName: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    /** Ending line (0-indexed, inclusive) */
+    endLine: number;
+
+    /** Big-O notation (e.g. "O(n·C)", "O(n log n)", "O(1)") */
+    notation: string;
+
+    /** Highest polynomial degree; Infinity for exponential growth */
+    degree: number;
+
+    /** Symbols used in the notation, in order of appearance */
+    symbols: GrowthSymbol[];
+
+    /** Loops, calls and recursion that contributed, in source order */
+    reasons: GrowthReason[];
+
+    /** One-line summary (e.g. "O(n·C) nested loop over input-sized data") */
+    summary: string;
+}
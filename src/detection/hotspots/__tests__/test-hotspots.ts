@@ -0,0 +1,92 @@
+/**
+ * Test for HotspotRanker and git log parsing
+ *
+ * Run with: npx ts-node src/detection/hotspots/__tests__/test-hotspots.ts
+ */
+
+import { HotspotRanker } from '../hotspotRanker';
+import { parseGitLog } from '../gitChurn';
+import { createChecks } from '../../../__tests__/checks';
+
+const complexGo = `package billing
+
+func reconcile(items []Item, ledger map[string]int) int {
+    drift := 0
+    for _, item := range items {
+        if expected, ok := ledger[item.ID]; ok {
+            if expected != item.Amount {
+                for _, adj := range item.Adjustments {
+                    if adj.Applied && adj.Amount > 0 {
+                        drift += adj.Amount
+                    }
+                }
+            }
+        } else {
+            drift++
+        }
+    }
+    return drift
+}
+
+func total(items []Item) int {
+    sum := 0
+    for _, item := range items {
+        sum += item.Amount
+    }
+    return sum
+}
+`;
+
+const simpleGo = `package config
+
+func defaultPort() int {
+    return 8080
+}
+`;
+
+function testHotspots(): boolean {
+    console.log('\n=== Testing HotspotRanker ===\n');
+    const { ok: check, finish } = createChecks();
+
+    // Test 1: git log parsing
+    console.log('Test 1: Parse git log --name-only output');
+    const log = '\x1e\nbilling/reconcile.go\nconfig/port.go\n' +
+        '\x1e\nbilling/reconcile.go\n' +
+        '\x1e\n' +
+        '\x1e\nbilling/reconcile.go\nREADME.md\n';
+    const churn = parseGitLog(log);
+    check('reconcile.go touched by 3 commits', churn.get('billing/reconcile.go') === 3);
+    check('port.go touched by 1 commit', churn.get('config/port.go') === 1);
+    check('empty commits ignored', churn.size === 3);
+
+    // Test 2: churn x complexity ordering
+    console.log('\nTest 2: Rank files by churn x complexity');
+    const ranked = HotspotRanker.rank([
+        { filePath: 'config/port.go', churn: 10, sourceCode: simpleGo },
+        { filePath: 'billing/reconcile.go', churn: 6, sourceCode: complexGo },
+        { filePath: 'billing/unchanged.go', churn: 0, sourceCode: complexGo }
+    ]);
+
+    for (const file of ranked) {
+        console.log(`  #${file.rank} ${file.filePath}: score ${file.score.toFixed(2)} (${file.churn} commits, cognitive ${file.cognitiveComplexity}, NLOC ${file.nloc})`);
+    }
+
+    check('files without churn are dropped', ranked.length === 2);
+    check('complex file outranks frequently changed simple file',
+        ranked[0]?.filePath === 'billing/reconcile.go');
+    check('hottest file scores 1', ranked[0]?.score === 1);
+
+    // Test 3: function ranking
+    console.log('\nTest 3: Rank functions');
+    const functions = HotspotRanker.topFunctions(ranked, 2);
+    for (const func of functions) {
+        console.log(`  ${func.filePath} ${func.functionName}: score ${func.score.toFixed(2)} (cognitive ${func.cognitiveComplexity})`);
+    }
+    check('reconcile is the hottest function', functions[0]?.functionName === 'reconcile');
+    check('limit is respected', functions.length === 2);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testHotspots() ? 0 : 1);
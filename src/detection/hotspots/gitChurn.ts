@@ -0,0 +1,62 @@
+/**
+ * Git Churn
+ * Counts how many commits touched each file, using git log
+ */
+
+import { execFile } from 'child_process';
+
+/**
+ * Record separator printed before each commit so file lists can be split apart
+ * (git renders %x1e as this character)
+ */
+const COMMIT_MARKER = '\x1e';
+
+/**
+ * Count commits per file from `git log --name-only` output
+ *
+ * @param output - Output of git log --name-only --format=%x1e
+ * @returns Map of repo-relative path to number of commits
+ */
+export function parseGitLog(output: string): Map<string, number> {
+    const churn = new Map<string, number>();
+
+    for (const commit of output.split(COMMIT_MARKER)) {
+        // A file listed twice in one commit (rename edge cases) still counts once
+        const files = new Set(
+            commit.split('\n').map(line => line.trim()).filter(line => line)
+        );
+        for (const file of files) {
+            churn.set(file, (churn.get(file) || 0) + 1);
+        }
+    }
+
+    return churn;
+}
+
+/**
+ * Read per-file commit counts for a repository
+ *
+ * @param repoRoot - Directory inside the git repository
+ * @param days - Only count commits from the last N days
+ * @returns Map of repo-relative path to number of commits
+ */
+export function readGitChurn(repoRoot: string, days: number): Promise<Map<string, number>> {
+    const args = [
+        'log',
+        '--no-merges',
+        '--name-only',
+        '--relative',
+        '--format=%x1e',
+        `--since=${days} days ago`
+    ];
+
+    return new Promise((resolve, reject) => {
+        execFile('git', args, { cwd: repoRoot, maxBuffer: 64 * 1024 * 1024 }, (error, stdout) => {
+            if (error) {
+                reject(error);
+                return;
+            }
+            resolve(parseGitLog(stdout));
+        });
+    });
+}
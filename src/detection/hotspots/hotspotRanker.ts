@@ -0,0 +1,91 @@
+/**
+ * Hotspot Ranker
+ * Ranks files and functions by change frequency x complexity
+ *
+ * Follows Adam Tornhill's hotspot analysis: complex code that rarely changes
+ * and simple code that changes all the time are both low risk. The files
+ * worth refactoring first are the ones that are complex AND keep changing.
+ *
+ * Complexity is cognitive complexity plus one per line of code, so a long
+ * flat file still registers. Function hotspots use the file's churn, since
+ * commit history is tracked per file.
+ */
+
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { FileHotspot, FunctionHotspot, HotspotInput } from './types';
+
+export class HotspotRanker {
+    /**
+     * Rank files by churn x complexity
+     *
+     * @param inputs - Files with their commit counts
+     * @returns Files with non-zero churn, hottest first
+     */
+    static rank(inputs: HotspotInput[]): FileHotspot[] {
+        const files: FileHotspot[] = [];
+
+        for (const input of inputs) {
+            if (input.churn <= 0) {
+                continue;
+            }
+
+            const analysis = ComplexityAnalyzer.forFile(input.filePath).analyze(input.sourceCode);
+            const functions: FunctionHotspot[] = analysis.functions
+                .filter(func => func.name !== '*global*')
+                .map(func => ({
+                    filePath: input.filePath,
+                    functionName: func.name,
+                    startLine: func.startLine,
+                    cognitiveComplexity: func.cognitiveComplexity,
+                    nloc: func.nloc,
+                    score: input.churn * this.weight(func.cognitiveComplexity, func.nloc)
+                }));
+
+            files.push({
+                filePath: input.filePath,
+                rank: 0,
+                churn: input.churn,
+                cognitiveComplexity: analysis.totalCognitive,
+                nloc: analysis.nloc,
+                score: input.churn * this.weight(analysis.totalCognitive, analysis.nloc),
+                functions
+            });
+        }
+
+        // Normalize against the hottest file/function so scores read as 0-1
+        const maxFileScore = Math.max(0, ...files.map(f => f.score));
+        const maxFunctionScore = Math.max(0, ...files.flatMap(f => f.functions.map(fn => fn.score)));
+
+        for (const file of files) {
+            file.score = maxFileScore > 0 ? file.score / maxFileScore : 0;
+            for (const func of file.functions) {
+                func.score = maxFunctionScore > 0 ? func.score / maxFunctionScore : 0;
+            }
+            file.functions.sort((a, b) => b.score - a.score);
+        }
+
+        files.sort((a, b) => b.score - a.score);
+        files.forEach((file, index) => {
+            file.rank = index + 1;
+        });
+
+        return files;
+    }
+
+    /**
+     * Rank functions across all files
+     *
+     * @param files - Result of rank()
+     * @param limit - Maximum number of functions to return
+     */
+    static topFunctions(files: FileHotspot[], limit: number = 20): FunctionHotspot[] {
+        return files
+            .flatMap(file => file.functions)
+            .sort((a, b) => b.score - a.score)
+            .slice(0, limit);
+    }
+
+    private static weight(cognitiveComplexity: number, nloc: number): number {
+        return cognitiveComplexity + nloc;
+    }
+}
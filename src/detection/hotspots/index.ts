@@ -0,0 +1,16 @@
+/**
+ * Hotspot Module
+ *
+ * Ranks files and functions by git churn x complexity
+ *
+ * @module hotspots
+ */
+
+export { HotspotRanker } from './hotspotRanker';
+export { parseGitLog, readGitChurn } from './gitChurn';
+
+export type {
+    HotspotInput,
+    FileHotspot,
+    FunctionHotspot
+} from './types';
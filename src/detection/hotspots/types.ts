@@ -0,0 +1,57 @@
+/**
+ * Type definitions for hotspot ranking
+ */
+
+/**
+ * A file to rank, with its change frequency
+ */
+export interface HotspotInput {
+    /** File path (as reported in results) */
+    filePath: string;
+
+    /** Number of commits that touched the file */
+    churn: number;
+
+    /** Current file contents */
+    sourceCode: string;
+}
+
+/**
+ * A function ranked by its file's churn and its own complexity
+ */
+export interface FunctionHotspot {
+    filePath: string;
+    functionName: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    cognitiveComplexity: number;
+    nloc: number;
+
+    /** Churn x complexity, normalized to 0-1 across the ranked set */
+    score: number;
+}
+
+/**
+ * A file ranked by churn x complexity
+ */
+export interface FileHotspot {
+    filePath: string;
+    rank: number;
+
+    /** Number of commits that touched the file */
+    churn: number;
+
+    /** Sum of cognitive complexity over the file's functions */
+    cognitiveComplexity: number;
+
+    /** Lines of code */
+    nloc: number;
+
+    /** Churn x complexity, normalized to 0-1 across the ranked set */
+    score: number;
+
+    /** The file's functions, hottest first */
+    functions: FunctionHotspot[];
+}
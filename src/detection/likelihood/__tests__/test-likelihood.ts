@@ -0,0 +1,130 @@
+/**
+ * Test for LikelihoodDetector
+ *
+ * Compares a terse hand-written file against a heavily narrated,
+ * templated one and checks the signals and combined probability.
+ *
+ * Run with: npx ts-node src/detection/likelihood/__tests__/test-likelihood.ts
+ */
+
+import { LikelihoodDetector } from '../detector';
+import { createChecks } from '../../../__tests__/checks';
+
+const handWritten = `package kv
+
+import "sync"
+
+// Store is safe for concurrent use.
+type Store struct {
+    mu sync.RWMutex
+    m  map[string][]byte
+}
+
+func (s *Store) Get(k string) ([]byte, bool) {
+    s.mu.RLock()
+    defer s.mu.RUnlock()
+    v, ok := s.m[k]
+    return v, ok
+}
+
+// Compact drops tombstones; see #412 for why we don't shrink here.
+func (s *Store) Compact(max int) (n int, err error) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    for k, v := range s.m {
+        if len(v) == 0 {
+            delete(s.m, k)
+            n++
+            if n >= max {
+                return n, nil
+            }
+        }
+    }
+    return n, nil
+}
+
+func ok(b []byte) bool { return b != nil }
+`;
+
+const generated = `
+// This function fetches the user profile from the API
+function fetchUserProfile(userIdentifier: string): Promise<UserProfile> {
+    // Initialize the request configuration
+    const requestConfiguration = { method: 'GET', headers: defaultRequestHeaders };
+    // Return the result of the request
+    return performHttpRequest(\`/users/\${userIdentifier}\`, requestConfiguration);
+}
+
+// This function fetches the account settings from the API
+function fetchAccountSettings(accountIdentifier: string): Promise<AccountSettings> {
+    // Initialize the request configuration
+    const requestConfiguration = { method: 'GET', headers: defaultRequestHeaders };
+    // Return the result of the request
+    return performHttpRequest(\`/accounts/\${accountIdentifier}\`, requestConfiguration);
+}
+
+// This function fetches the billing history from the API
+function fetchBillingHistory(customerIdentifier: string): Promise<BillingHistory> {
+    // Initialize the request configuration
+    const requestConfiguration = { method: 'GET', headers: defaultRequestHeaders };
+    // Return the result of the request
+    return performHttpRequest(\`/billing/\${customerIdentifier}\`, requestConfiguration);
+}
+
+// Helper function to update the notification preferences
+function updateNotificationPreferences(preferenceIdentifier: string): Promise<NotificationPreferences> {
+    // Initialize the request configuration
+    const requestConfiguration = { method: 'PUT', headers: defaultRequestHeaders };
+    // Replace with your actual endpoint
+    return performHttpRequest(\`/preferences/\${preferenceIdentifier}\`, requestConfiguration);
+}
+`;
+
+const urlComments = `
+const baseUrl = "http://api.example.com/v1"; // Initialize the base URL
+const docsUrl = 'https://docs.example.com'; // Return the documentation link
+`;
+
+function testLikelihood(): boolean {
+    console.log('\n=== Testing LikelihoodDetector ===\n');
+    const { ok: check, finish } = createChecks();
+
+    const human = LikelihoodDetector.assess(handWritten, 'store.go');
+    const machine = LikelihoodDetector.assess(generated, 'api.ts');
+
+    for (const [label, result] of [['Hand-written Go', human], ['Generated TypeScript', machine]] as const) {
+        console.log(`${label}: ${result.level} (${Math.round(result.probability * 100)}%)`);
+        for (const signal of result.signals) {
+            console.log(`  ${signal.fired ? '•' : ' '} ${signal.label}: ${signal.score.toFixed(2)} - ${signal.detail}`);
+        }
+    }
+    console.log();
+
+    check('hand-written file is Unlikely', human.level === 'Unlikely');
+    check('generated file is Likely', machine.level === 'Likely');
+    check('comment phrasing fires on generated file',
+        machine.fired.some(s => s.id === 'commentPhrasing'));
+    check('boilerplate fires on generated file',
+        machine.fired.some(s => s.id === 'boilerplateRatio'));
+    check('comment phrasing does not fire on hand-written file',
+        !human.fired.some(s => s.id === 'commentPhrasing'));
+    check('edit provenance is skipped without tracking data',
+        !machine.signals.some(s => s.id === 'editProvenance'));
+
+    // Provenance alone should move an otherwise neutral file
+    const tracked = LikelihoodDetector.assess(handWritten, 'store.go', { aiLinesChanged: 38, aiEditCount: 2 });
+    check('full AI edit provenance raises probability', tracked.probability > human.probability);
+    check('edit provenance fires when the whole file was pasted',
+        tracked.fired.some(s => s.id === 'editProvenance'));
+
+    // A '//' inside a string doesn't hide the trailing comment after it
+    const urls = LikelihoodDetector.assess(urlComments, 'client.ts');
+    const phrasing = urls.signals.find(s => s.id === 'commentPhrasing');
+    console.log(`  commentPhrasing: ${phrasing?.detail}`);
+    check('trailing comments after URL strings are found', phrasing?.detail.startsWith('2 of 2 comments') === true);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testLikelihood() ? 0 : 1);
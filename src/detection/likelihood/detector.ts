@@ -0,0 +1,177 @@
+/**
+ * Machine-Generated Code Likelihood Detector
+ *
+ * A Voight-Kampff test for source files: runs a set of stylometric signals
+ * and combines them into a weighted probability. No single signal is
+ * conclusive - the explanation lists which ones fired so the reader can judge.
+ */
+
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { Tokenizer } from '../complexity/tokenizer';
+import { SIGNALS } from './signals';
+import { FileProvenance, LikelihoodResult, SignalInput } from './types';
+
+/**
+ * Keywords shared by the supported languages, excluded from identifier statistics
+ */
+const KEYWORDS = new Set([
+    'if', 'else', 'elif', 'for', 'while', 'do', 'switch', 'case', 'default', 'break',
+    'continue', 'return', 'func', 'function', 'def', 'class', 'struct', 'interface',
+    'type', 'const', 'let', 'var', 'new', 'this', 'self', 'import', 'from', 'export',
+    'package', 'try', 'catch', 'except', 'finally', 'throw', 'raise', 'async', 'await',
+    'true', 'false', 'null', 'nil', 'None', 'True', 'False', 'undefined', 'in', 'of',
+    'range', 'go', 'defer', 'select', 'map', 'chan', 'and', 'or', 'not', 'is', 'with',
+    'as', 'pass', 'lambda', 'yield', 'typeof', 'instanceof', 'extends', 'implements',
+    'public', 'private', 'protected', 'static', 'readonly', 'void'
+]);
+
+/**
+ * Likelihood level cutoffs (probability)
+ */
+const LEVELS = {
+    possible: 0.35,
+    likely: 0.6
+};
+
+export class LikelihoodDetector {
+    /**
+     * Assess how likely a file is to be machine-generated
+     *
+     * @param sourceCode - Full file contents
+     * @param filename - File name for language detection
+     * @param provenance - Voight's AI edit history for the file, if tracked
+     */
+    static assess(sourceCode: string, filename: string, provenance?: FileProvenance): LikelihoodResult {
+        const input = this.prepare(sourceCode, filename, provenance);
+
+        const signals = SIGNALS
+            .map(signal => signal(input))
+            .filter((signal): signal is NonNullable<typeof signal> => signal !== null);
+
+        const totalWeight = signals.reduce((sum, s) => sum + s.weight, 0);
+        const probability = totalWeight > 0
+            ? signals.reduce((sum, s) => sum + s.score * s.weight, 0) / totalWeight
+            : 0;
+
+        return {
+            probability,
+            level: this.getLikelihoodLevel(probability),
+            signals,
+            fired: signals
+                .filter(s => s.fired)
+                .sort((a, b) => b.score * b.weight - a.score * a.weight)
+        };
+    }
+
+    /**
+     * Get human-readable likelihood level
+     */
+    static getLikelihoodLevel(probability: number): string {
+        if (probability >= LEVELS.likely) return 'Likely';
+        if (probability >= LEVELS.possible) return 'Possible';
+        return 'Unlikely';
+    }
+
+    /**
+     * Split the file into comments and code and collect the shared inputs
+     */
+    private static prepare(sourceCode: string, filename: string, provenance?: FileProvenance): SignalInput {
+        const lines = sourceCode.split('\n');
+        const isPython = filename.toLowerCase().endsWith('.py');
+
+        const comments: string[] = [];
+        const codeLines: string[] = [];
+        let blockEnd: string | null = null;
+
+        for (const line of lines) {
+            const trimmed = line.trim();
+            if (!trimmed) {
+                continue;
+            }
+
+            // Inside a /* */ block or Python docstring
+            if (blockEnd) {
+                const endIndex = trimmed.indexOf(blockEnd);
+                const text = endIndex >= 0 ? trimmed.substring(0, endIndex) : trimmed;
+                this.pushComment(comments, text);
+                if (endIndex >= 0) {
+                    blockEnd = null;
+                }
+                continue;
+            }
+
+            const docstring = isPython ? trimmed.match(/^("""|''')/) : null;
+            if (docstring || trimmed.startsWith('/*')) {
+                const marker = docstring ? docstring[1] : '/*';
+                const closer = docstring ? docstring[1] : '*/';
+                const rest = trimmed.substring(marker.length);
+                const endIndex = rest.indexOf(closer);
+                this.pushComment(comments, endIndex >= 0 ? rest.substring(0, endIndex) : rest);
+                if (endIndex < 0) {
+                    blockEnd = closer;
+                }
+                continue;
+            }
+
+            // Line or trailing comment
+            const marker = isPython ? '#' : '//';
+            const commentIndex = this.commentStart(line, marker);
+            if (commentIndex >= 0) {
+                this.pushComment(comments, line.substring(commentIndex + marker.length));
+                const code = line.substring(0, commentIndex).trim();
+                if (code) {
+                    codeLines.push(code);
+                }
+                continue;
+            }
+
+            codeLines.push(line);
+        }
+
+        const code = codeLines.join('\n');
+        const identifiers = Tokenizer.generateTokens(code)
+            .filter(token => /^[A-Za-z_]\w*$/.test(token) && !KEYWORDS.has(token));
+
+        const functions = ComplexityAnalyzer.forFile(filename).analyze(sourceCode).functions;
+
+        return {
+            comments,
+            codeLines,
+            identifiers,
+            functions,
+            totalLines: lines.filter(line => line.trim()).length,
+            provenance
+        };
+    }
+
+    private static pushComment(comments: string[], text: string): void {
+        // Drop leading '*' of block comment continuation lines
+        const cleaned = text.replace(/^\s*\*+/, '').trim();
+        if (cleaned) {
+            comments.push(cleaned);
+        }
+    }
+
+    /**
+     * Where a line or trailing comment starts, or -1 when there is none
+     * Markers inside string literals (e.g. "http://...") are skipped
+     */
+    private static commentStart(line: string, marker: string): number {
+        let quote: string | null = null;
+        for (let i = 0; i < line.length; i++) {
+            const ch = line[i];
+            if (quote) {
+                if (ch === '\\') {
+                    i++;
+                } else if (ch === quote) {
+                    quote = null;
+                }
+            } else if (ch === '"' || ch === "'" || ch === '`') {
+                quote = ch;
+            } else if (line.startsWith(marker, i)) {
+                return i;
+            }
+        }
+        return -1;
+    }
+}
@@ -0,0 +1,37 @@
+/**
+ * Machine-Generated Code Likelihood Module
+ *
+ * Combines stylometric signals into a per-file probability that the
+ * code was machine-generated, with an explanation of which signals fired
+ *
+ * @module likelihood
+ */
+
+export { LikelihoodDetector } from './detector';
+export { SIGNAL_WEIGHTS } from './signals';
+
+export type {
+    SignalId,
+    SignalResult,
+    FileProvenance,
+    LikelihoodResult
+} from './types';
+
+/**
+ * Quick API: Assess a file
+ *
+ * @param code - Full file contents
+ * @param filename - Filename for language detection (e.g., "app.ts", "main.go")
+ * @returns Probability, level and the signals that fired
+ *
+ * @example
+ * ```typescript
+ * const result = assessFile(code, 'handlers.go');
+ * console.log(`${result.level} (${Math.round(result.probability * 100)}%)`);
+ * result.fired.forEach(s => console.log(`${s.label}: ${s.detail}`));
+ * ```
+ */
+export function assessFile(code: string, filename: string) {
+    const { LikelihoodDetector } = require('./detector');
+    return LikelihoodDetector.assess(code, filename);
+}
@@ -0,0 +1,218 @@
+/**
+ * Stylometric Signals
+ *
+ * Each signal maps one property of a file to a 0-1 score, where 1 looks
+ * machine-generated. A signal returns null when the file does not have
+ * enough material for it to mean anything (e.g. no comments at all).
+ */
+
+import { SignalInput, SignalResult, SignalId } from './types';
+
+/**
+ * Score at or above which a signal counts as evidence
+ */
+const FIRED_THRESHOLD = 0.5;
+
+/**
+ * Relative weights of each signal in the combined probability
+ */
+export const SIGNAL_WEIGHTS: Record<SignalId, number> = {
+    commentPhrasing: 0.3,
+    identifierEntropy: 0.15,
+    boilerplateRatio: 0.15,
+    uniformComplexity: 0.15,
+    editProvenance: 0.25
+};
+
+/**
+ * Comment phrasings typical of generated code: narrating the next line,
+ * numbered steps, placeholders left for the reader to fill in
+ */
+const GENERATED_COMMENT_PATTERNS: RegExp[] = [
+    /^(this|the following) (function|method|class|code|component|helper|module)\b/i,
+    /^(helper|utility) (function|method) (to|for|that)\b/i,
+    /^step \d+/i,
+    /^(first|next|then|finally),/i,
+    /\b(here'?s|here is) (the|a|an)\b/i,
+    /\breplace (this |it )?with (your|the actual)\b/i,
+    /\byour[-_ ](api[-_ ]key|actual|own)\b/i,
+    /\b(example usage|usage example)\b/i,
+    /^\.\.\.\s*(rest|existing|other)\b/i,
+    /^(initialize|create|define|check if|return|handle|update|calculate|get|set) the\b/i,
+    /\b(for simplicity|in a real(-world)? (app|application|scenario)|edge cases?)\b/i,
+    /\bensure that\b/i
+];
+
+type Signal = (input: SignalInput) => SignalResult | null;
+
+/**
+ * Fraction of comments that use generated-code phrasing
+ */
+function commentPhrasing(input: SignalInput): SignalResult | null {
+    if (input.comments.length < 2) {
+        return null;
+    }
+
+    const matched = input.comments.filter(comment =>
+        GENERATED_COMMENT_PATTERNS.some(pattern => pattern.test(comment))
+    ).length;
+    const ratio = matched / input.comments.length;
+
+    return result(
+        'commentPhrasing',
+        'Comment phrasing',
+        scale(ratio, 0, 0.4),
+        `${matched} of ${input.comments.length} comments narrate the code or use template phrasing`
+    );
+}
+
+/**
+ * Average character entropy of distinct identifiers
+ * Long descriptive names (calculateTotalPrice) score high; terse names (i, err, ctx) score low
+ */
+function identifierEntropy(input: SignalInput): SignalResult | null {
+    const distinct = [...new Set(input.identifiers)];
+    if (distinct.length < 5) {
+        return null;
+    }
+
+    const mean = distinct.reduce((sum, name) => sum + shannonEntropy(name), 0) / distinct.length;
+
+    return result(
+        'identifierEntropy',
+        'Identifier entropy',
+        scale(mean, 2.3, 3.3),
+        `${distinct.length} distinct identifiers average ${mean.toFixed(2)} bits per character`
+    );
+}
+
+/**
+ * Fraction of code lines whose shape repeats at least three times
+ * Shape replaces identifiers, numbers and strings so templated lines collapse together
+ */
+function boilerplateRatio(input: SignalInput): SignalResult | null {
+    const lines = input.codeLines
+        .map(line => line.trim())
+        .filter(line => line.length >= 8);
+    if (lines.length < 10) {
+        return null;
+    }
+
+    const shapes = lines.map(lineShape);
+    const counts = new Map<string, number>();
+    for (const shape of shapes) {
+        counts.set(shape, (counts.get(shape) || 0) + 1);
+    }
+
+    const repeated = shapes.filter(shape => (counts.get(shape) || 0) >= 3).length;
+    const ratio = repeated / lines.length;
+
+    return result(
+        'boilerplateRatio',
+        'Boilerplate ratio',
+        scale(ratio, 0.1, 0.4),
+        `${Math.round(ratio * 100)}% of code lines follow a repeated template`
+    );
+}
+
+/**
+ * How similar functions are in size and cognitive complexity
+ * Hand-written files mix trivial helpers with a few gnarly functions;
+ * generated files tend to be evenly sized
+ */
+function uniformComplexity(input: SignalInput): SignalResult | null {
+    const functions = input.functions.filter(f => f.name !== '*global*');
+    if (functions.length < 3) {
+        return null;
+    }
+
+    const sizeVariation = coefficientOfVariation(functions.map(f => f.nloc));
+    const cognitiveVariation = coefficientOfVariation(functions.map(f => f.cognitiveComplexity + 1));
+    const variation = (sizeVariation + cognitiveVariation) / 2;
+
+    return result(
+        'uniformComplexity',
+        'Uniform complexity',
+        1 - scale(variation, 0.15, 0.6),
+        `${functions.length} functions vary by ${Math.round(variation * 100)}% in size and cognitive complexity`
+    );
+}
+
+/**
+ * Share of the file that arrived through detected AI edits
+ */
+function editProvenance(input: SignalInput): SignalResult | null {
+    if (!input.provenance || input.totalLines === 0) {
+        return null;
+    }
+
+    const { aiLinesChanged, aiEditCount } = input.provenance;
+    const ratio = Math.min(1, aiLinesChanged / input.totalLines);
+
+    return result(
+        'editProvenance',
+        'Edit provenance',
+        scale(ratio, 0, 0.8),
+        `${aiLinesChanged} lines in ${aiEditCount} detected AI edit${aiEditCount === 1 ? '' : 's'} (file has ${input.totalLines} lines)`
+    );
+}
+
+/**
+ * All signals in evaluation order
+ */
+export const SIGNALS: Signal[] = [
+    commentPhrasing,
+    identifierEntropy,
+    boilerplateRatio,
+    uniformComplexity,
+    editProvenance
+];
+
+function result(id: SignalId, label: string, score: number, detail: string): SignalResult {
+    return {
+        id,
+        label,
+        score,
+        weight: SIGNAL_WEIGHTS[id],
+        fired: score >= FIRED_THRESHOLD,
+        detail
+    };
+}
+
+/**
+ * Linearly map value from [low, high] to [0, 1], clamped
+ */
+function scale(value: number, low: number, high: number): number {
+    return Math.max(0, Math.min(1, (value - low) / (high - low)));
+}
+
+function shannonEntropy(text: string): number {
+    const counts = new Map<string, number>();
+    for (const ch of text.toLowerCase()) {
+        counts.set(ch, (counts.get(ch) || 0) + 1);
+    }
+
+    let entropy = 0;
+    for (const count of counts.values()) {
+        const p = count / text.length;
+        entropy -= p * Math.log2(p);
+    }
+    return entropy;
+}
+
+function coefficientOfVariation(values: number[]): number {
+    const mean = values.reduce((sum, v) => sum + v, 0) / values.length;
+    if (mean === 0) {
+        return 0;
+    }
+    const variance = values.reduce((sum, v) => sum + (v - mean) ** 2, 0) / values.length;
+    return Math.sqrt(variance) / mean;
+}
+
+function lineShape(line: string): string {
+    return line
+        .replace(/"(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|`[^`]*`/g, 'S')
+        .replace(/\b\d[\w.]*\b/g, '0')
+        .replace(/\b[A-Za-z_]\w*\b/g, 'x')
+        .replace(/\s+/g, ' ');
+}
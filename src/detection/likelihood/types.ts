@@ -0,0 +1,90 @@
+/**
+ * Type definitions for machine-generated code likelihood
+ */
+
+import { FunctionInfo } from '../complexity/types';
+
+/**
+ * Identifiers for the individual stylometric signals
+ */
+export type SignalId =
+    | 'commentPhrasing'
+    | 'identifierEntropy'
+    | 'boilerplateRatio'
+    | 'uniformComplexity'
+    | 'editProvenance';
+
+/**
+ * Result of evaluating one signal against a file
+ */
+export interface SignalResult {
+    /** Signal identifier */
+    id: SignalId;
+
+    /** Human-readable signal name */
+    label: string;
+
+    /** Strength of the signal (0 = human-like, 1 = strongly machine-like) */
+    score: number;
+
+    /** Relative weight of this signal in the combined probability */
+    weight: number;
+
+    /** Whether the signal is strong enough to count as evidence */
+    fired: boolean;
+
+    /** Explanation of what was measured */
+    detail: string;
+}
+
+/**
+ * Voight's own record of how the file was edited
+ * Stands in for commit metadata: lines that arrived through detected AI edits
+ */
+export interface FileProvenance {
+    /** Lines changed by detected AI edits */
+    aiLinesChanged: number;
+
+    /** Number of detected AI edits */
+    aiEditCount: number;
+}
+
+/**
+ * Pre-processed view of a file shared by all signals
+ */
+export interface SignalInput {
+    /** Comment text with comment markers stripped, one entry per comment line */
+    comments: string[];
+
+    /** Non-blank lines with comments removed */
+    codeLines: string[];
+
+    /** Identifier tokens in code (keywords excluded) */
+    identifiers: string[];
+
+    /** Functions found by the complexity analyzer */
+    functions: FunctionInfo[];
+
+    /** Total number of lines in the file */
+    totalLines: number;
+
+    /** Edit history, when the file has been tracked */
+    provenance?: FileProvenance;
+}
+
+/**
+ * Combined likelihood assessment for a file
+ */
+export interface LikelihoodResult {
+    /** Probability (0-1) that the file is machine-generated */
+    probability: number;
+
+    /** Human-readable level: Unlikely, Possible or Likely */
+    level: string;
+
+    /** Every signal that could be evaluated */
+    signals: SignalResult[];
+
+    /** Signals that fired, strongest first */
+    fired: SignalResult[];
+}
@@ -0,0 +1,83 @@
+/**
+ * Test for the plugin runner
+ *
+ * Run with: npx ts-node src/detection/plugins/__tests__/test-plugins.ts
+ */
+
+import { buildPluginRequest, parsePluginOutput, runPlugin } from '../pluginRunner';
+import { createChecks } from '../../../__tests__/checks';
+
+const goCode = `package api
+
+func GetUser(w http.ResponseWriter, r *http.Request) {
+    if r.Method != http.MethodGet {
+        return
+    }
+}
+
+func fetch(id string) {
+}
+`;
+
+// Flags exported Go functions that don't follow the <Verb><Noun>Handler naming rule
+const namingPlugin = `
+let input = '';
+process.stdin.on('data', chunk => input += chunk);
+process.stdin.on('end', () => {
+    const request = JSON.parse(input);
+    const findings = request.functions
+        .filter(f => /^[A-Z]/.test(f.name) && !f.name.endsWith('Handler'))
+        .map(f => ({ ruleId: 'handler-naming', message: f.name + ' should end in Handler', startLine: f.startLine, functionName: f.name }));
+    console.log(JSON.stringify({ findings }));
+});
+`;
+
+async function testPlugins(): Promise<boolean> {
+    console.log('\n=== Testing plugin runner ===\n');
+    const { check, finish } = createChecks();
+
+    // Test 1: request contents
+    console.log('Test 1: Request');
+    const request = buildPluginRequest(goCode, 'api/users.go');
+    check('language', request.language, 'go');
+    check('functions', request.functions.map(f => `${f.name}@${f.startLine}`), ['GetUser@2', 'fetch@8']);
+    check('parameter count', request.functions[0].parameterCount, 2);
+
+    // Test 2: output parsing
+    console.log('\nTest 2: Output parsing');
+    const parsed = parsePluginOutput('org', JSON.stringify({
+        findings: [
+            { ruleId: 'ctx', message: 'no context', startLine: 3, severity: 'error' },
+            { ruleId: 'bad' },
+            { ruleId: 'naming', message: 'rename', startLine: 8, endLine: 9, severity: 'loud' }
+        ]
+    }));
+    check('rule IDs are prefixed, malformed entries skipped', parsed.map(f => f.ruleId), ['org/ctx', 'org/naming']);
+    check('defaults', [parsed[0].endLine, parsed[1].severity], [3, 'warning']);
+
+    let rejected = false;
+    try {
+        parsePluginOutput('org', '[]');
+    } catch {
+        rejected = true;
+    }
+    check('wrong shape is rejected', rejected, true);
+
+    // Test 3: spawning a plugin
+    console.log('\nTest 3: Running a plugin');
+    const findings = await runPlugin({ name: 'org', command: process.execPath, args: ['-e', namingPlugin] }, request, process.cwd());
+    check('findings from plugin', findings.map(f => `${f.ruleId}:${f.functionName}:${f.startLine}`), ['org/handler-naming:GetUser:2']);
+
+    let failed = false;
+    try {
+        await runPlugin({ name: 'org', command: process.execPath, args: ['-e', 'process.exit(2)'] }, request, process.cwd());
+    } catch {
+        failed = true;
+    }
+    check('non-zero exit fails the run', failed, true);
+
+    return finish();
+}
+
+// Run the test
+testPlugins().then(ok => process.exit(ok ? 0 : 1));
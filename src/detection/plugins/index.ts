@@ -0,0 +1,17 @@
+/**
+ * Plugin Module
+ *
+ * Custom detectors run as external processes over a JSON protocol
+ *
+ * @module plugins
+ */
+
+export { buildPluginRequest, parsePluginOutput, runPlugin } from './pluginRunner';
+
+export type {
+    PluginConfig,
+    PluginFunction,
+    PluginRequest,
+    PluginFinding,
+    PluginSeverity
+} from './types';
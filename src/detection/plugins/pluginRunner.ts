@@ -0,0 +1,114 @@
+/**
+ * Plugin Runner
+ * Runs custom detectors over an exec-based JSON protocol
+ *
+ * Voight spawns the plugin, writes a PluginRequest (the file and its parsed
+ * function metadata) to stdin as one JSON document, and reads
+ * { "findings": [...] } from stdout. Anything on stderr is ignored, and a
+ * non-zero exit, a timeout or malformed output fails the run.
+ */
+
+import { execFile } from 'child_process';
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { PluginConfig, PluginFinding, PluginRequest, PluginSeverity } from './types';
+
+const DEFAULT_TIMEOUT_MS = 10000;
+
+const SEVERITIES: PluginSeverity[] = ['error', 'warning', 'info'];
+
+/**
+ * Build the request sent to plugins for a file
+ *
+ * @param sourceCode - Full file contents
+ * @param filePath - File path, also used for language detection
+ */
+export function buildPluginRequest(sourceCode: string, filePath: string): PluginRequest {
+    const analyzer = ComplexityAnalyzer.forFile(filePath);
+    const functions = analyzer.analyze(sourceCode).functions
+        .filter(func => func.name !== '*global*')
+        .map(func => ({
+            name: func.name,
+            longName: func.longName,
+            startLine: func.startLine,
+            endLine: func.endLine,
+            cyclomaticComplexity: func.cyclomaticComplexity,
+            cognitiveComplexity: func.cognitiveComplexity,
+            nloc: func.nloc,
+            parameterCount: func.parameterCount,
+            maxNestingDepth: func.maxNestingDepth
+        }));
+
+    return {
+        version: 1,
+        filePath,
+        language: analyzer.getLanguage(),
+        sourceCode,
+        functions
+    };
+}
+
+/**
+ * Parse a plugin's stdout into findings
+ *
+ * @param pluginName - Prefix for rule IDs
+ * @param output - Plugin stdout
+ * @throws When the output is not { "findings": [...] }
+ */
+export function parsePluginOutput(pluginName: string, output: string): PluginFinding[] {
+    const parsed = JSON.parse(output);
+    if (!parsed || !Array.isArray(parsed.findings)) {
+        throw new Error('expected { "findings": [...] }');
+    }
+
+    const findings: PluginFinding[] = [];
+    for (const entry of parsed.findings) {
+        // Skip entries without the required fields rather than failing the whole run
+        if (!entry || typeof entry.ruleId !== 'string' || typeof entry.message !== 'string' ||
+            typeof entry.startLine !== 'number') {
+            continue;
+        }
+
+        findings.push({
+            ruleId: `${pluginName}/${entry.ruleId}`,
+            message: entry.message,
+            startLine: entry.startLine,
+            endLine: typeof entry.endLine === 'number' ? entry.endLine : entry.startLine,
+            functionName: typeof entry.functionName === 'string' ? entry.functionName : undefined,
+            severity: SEVERITIES.includes(entry.severity) ? entry.severity : 'warning'
+        });
+    }
+
+    return findings;
+}
+
+/**
+ * Run a plugin on a request
+ *
+ * @param plugin - Plugin to spawn
+ * @param request - File and function metadata
+ * @param cwd - Working directory for the plugin
+ * @returns Findings, with rule IDs prefixed by the plugin name
+ */
+export function runPlugin(plugin: PluginConfig, request: PluginRequest, cwd: string): Promise<PluginFinding[]> {
+    return new Promise((resolve, reject) => {
+        const child = execFile(plugin.command, plugin.args || [], {
+            cwd,
+            timeout: plugin.timeoutMs || DEFAULT_TIMEOUT_MS,
+            maxBuffer: 16 * 1024 * 1024
+        }, (error, stdout) => {
+            if (error) {
+                reject(error);
+                return;
+            }
+            try {
+                resolve(parsePluginOutput(plugin.name, stdout));
+            } catch (parseError) {
+                reject(new Error(`${plugin.name} returned invalid output: ${parseError}`));
+            }
+        });
+
+        // A plugin that exits without reading stdin is not an error by itself
+        child.stdin?.on('error', () => undefined);
+        child.stdin?.end(JSON.stringify(request));
+    });
+}
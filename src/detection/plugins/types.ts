@@ -0,0 +1,75 @@
+/**
+ * Type definitions for detector plugins
+ */
+
+/**
+ * A plugin from voight.plugins
+ */
+export interface PluginConfig {
+    /** Name shown as the diagnostic source and used to prefix rule IDs */
+    name: string;
+
+    /** Executable to spawn */
+    command: string;
+
+    args?: string[];
+
+    /** Kill the plugin after this long (default 10000) */
+    timeoutMs?: number;
+}
+
+/**
+ * Function metadata sent to plugins
+ */
+export interface PluginFunction {
+    name: string;
+    longName: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    /** Ending line (0-indexed, inclusive) */
+    endLine: number;
+
+    cyclomaticComplexity: number;
+    cognitiveComplexity: number;
+    nloc: number;
+    parameterCount: number;
+    maxNestingDepth: number;
+}
+
+/**
+ * Written to the plugin's stdin as one JSON document
+ */
+export interface PluginRequest {
+    /** Protocol version, bumped on breaking changes */
+    version: 1;
+
+    filePath: string;
+    language: string;
+    sourceCode: string;
+    functions: PluginFunction[];
+}
+
+export type PluginSeverity = 'error' | 'warning' | 'info';
+
+/**
+ * A finding reported by a plugin
+ */
+export interface PluginFinding {
+    /** Rule ID, prefixed with the plugin name ("<plugin>/<rule>") */
+    ruleId: string;
+
+    message: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    /** Ending line (0-indexed, inclusive; defaults to startLine) */
+    endLine: number;
+
+    functionName?: string;
+
+    /** Defaults to warning */
+    severity: PluginSeverity;
+}
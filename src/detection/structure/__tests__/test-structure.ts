@@ -0,0 +1,232 @@
+/**
+ * Test for StructureDetector
+ *
+ * Run with: npx ts-node src/detection/structure/__tests__/test-structure.ts
+ */
+
+import { StructureDetector } from '../structureDetector';
+import { ComplexityAnalyzer } from '../../complexity/analyzer';
+import { Language } from '../../complexity/types';
+import { createChecks } from '../../../__tests__/checks';
+
+const goCode = `package sync
+
+func apply(ctx context.Context, items []Item, opts Options, dry bool, log Logger, retries int) error {
+    for _, item := range items {
+        if item.Enabled {
+            for attempt := 0; attempt < retries; attempt++ {
+                if err := item.Run(ctx); err != nil {
+                    if !dry {
+                        log.Printf("retry %d", attempt)
+                    }
+                }
+            }
+        }
+    }
+    return nil
+}
+
+func name(m map[string]int, keys ...string) string { return keys[0] }
+`;
+
+const goSignatures = `package jobs
+
+func worker(jobs <-chan Job, out chan<- Result, wg *sync.WaitGroup) {
+    wg.Done()
+}
+
+func Retry(fn func(ctx context.Context, n int) (int, error), attempts int) error {
+    return nil
+}
+`;
+
+const goLiterals = `package server
+
+func defaults() Config {
+    ports := []int{80, 443, 8080, 8443, 9000, 9090}
+    routes := map[string]Route{
+        "/": {Handler: "index", Auth: false},
+        "/admin": {Handler: "admin", Auth: true, Roles: []string{"root", "ops"}},
+    }
+    return Config{
+        Host:    "localhost",
+        Ports:   ports,
+        Routes:  routes,
+        Timeout: 30,
+        TLS:     &tls.Config{MinVersion: tls.VersionTLS12},
+    }
+}
+`;
+
+const goSelects = `package pump
+
+func forward(ctx context.Context, in <-chan Event, out chan<- Event) {
+    for {
+        select {
+        case e := <-in:
+            out <- e
+        case <-time.Tick(time.Second):
+            flush()
+        }
+    }
+}
+
+func tryForward(in <-chan Event, out chan<- Event) {
+    select {
+    case e := <-in:
+        out <- e
+    default:
+    }
+}
+
+func forwardUntilDone(ctx context.Context, in <-chan Event, out chan<- Event) {
+    select {
+    case e := <-in:
+        out <- e
+    case <-ctx.Done():
+        return
+    }
+}
+`;
+
+const goImportsCode = `package report
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    log "github.com/sirupsen/logrus"
+)
+
+func Publish(w http.ResponseWriter, path string) error {
+    id := uuid.New()
+    body, err := os.ReadFile(path)
+    if err != nil {
+        log.Errorf("read %s: %v", path, err)
+        return fmt.Errorf("publish %s: %w", id, err)
+    }
+    var out map[string]string
+    _ = json.Unmarshal(body, &out)
+    w.Header().Set("X-Sent", time.Now().Format(time.RFC3339))
+    w.WriteHeader(http.StatusOK)
+    _, err = w.Write([]byte(strings.TrimSpace(string(body))))
+    return err
+}
+
+func Name(r *http.Request) string { return strings.ToLower(r.URL.Path) }
+`;
+
+const tsCode = `
+function configure(options: { host: string, port: number }, retries = Math.max(1, 2)) {
+    const a = 1; const b = 2;
+    for (let i = 0; i < retries; i++) {
+        connect(options.host, options.port);
+    }
+    return a + b;
+}
+`;
+
+const pyCode = `def walk(tree, visit):
+    for node in tree:
+        if node.children:
+            for child in node.children:
+                visit(child)
+    return tree
+`;
+
+function structureOf(code: string, filename: string, language: Language, functionName: string) {
+    const func = ComplexityAnalyzer.forFile(filename).analyze(code).functions.find(f => f.name === functionName);
+    return func ? StructureDetector.measure(func, code.split('\n'), language) : undefined;
+}
+
+function testStructureDetector(): boolean {
+    console.log('\n=== Testing StructureDetector ===\n');
+    const { check, finish } = createChecks();
+
+    // Test 1: measurements
+    console.log('Test 1: Go measurements');
+    const apply = structureOf(goCode, 'apply.go', Language.Go, 'apply');
+    check('apply nesting depth', apply?.nestingDepth, 5);
+    check('apply parameters', apply?.parameterCount, 6);
+    check('apply lines', apply?.lineCount, 14);
+    check('apply statements', apply?.statementCount, 8);
+    check('variadic/map parameters', structureOf(goCode, 'apply.go', Language.Go, 'name')?.parameterCount, 2);
+    check('channel direction parameters', structureOf(goSignatures, 'jobs.go', Language.Go, 'worker')?.parameterCount, 3);
+    check('function-typed parameters', structureOf(goSignatures, 'jobs.go', Language.Go, 'Retry')?.parameterCount, 2);
+
+    console.log('\nTest 2: TypeScript measurements');
+    const configure = structureOf(tsCode, 'config.ts', Language.TypeScript, 'configure');
+    check('commas in object types and defaults are not parameters', configure?.parameterCount, 2);
+    check('configure nesting depth', configure?.nestingDepth, 1);
+    check("statements joined with ';' count separately", configure?.statementCount, 5);
+
+    console.log('\nTest 3: Python measurements');
+    const walk = structureOf(pyCode, 'walk.py', Language.Python, 'walk');
+    check('walk nesting depth from indentation', walk?.nestingDepth, 3);
+    check('walk parameters', walk?.parameterCount, 2);
+
+    // Test 4: findings honour independent thresholds and disabled rules
+    console.log('\nTest 4: Findings');
+    const findings = new StructureDetector({ 'max-function-lines': false }).detect(goCode, 'apply.go');
+    for (const finding of findings) {
+        console.log(`  [${finding.ruleId}] line ${finding.startLine + 1}: ${finding.message}`);
+    }
+    const rules = findings.map(f => f.ruleId).sort().join(',');
+    check('rules fired', rules, 'max-nesting-depth,max-parameters');
+
+    const strict = new StructureDetector({ 'max-nesting-depth': 10, 'max-parameters': 10, 'max-file-lines': 5 })
+        .detect(goCode, 'apply.go');
+    check('file length rule fires alone', strict.filter(f => f.ruleId === 'max-file-lines').length, strict.length);
+
+    // Test 5: Go composite literals, struct and collection limits apart
+    console.log('\nTest 5: Composite literals');
+    const literals = (thresholds: ConstructorParameters<typeof StructureDetector>[0]) => new StructureDetector(thresholds)
+        .detect(goLiterals, 'server.go')
+        .filter(f => f.ruleId.endsWith('-literal-elements'))
+        .map(f => `${f.ruleId}:${f.functionName}:${f.startLine}:${f.value}`);
+
+    check('slice literal over its limit', literals({ 'max-collection-literal-elements': 5 }),
+        ['max-collection-literal-elements:defaults:3:6']);
+    check('struct literal over its limit', literals({ 'max-struct-literal-elements': 4 }),
+        ['max-struct-literal-elements:defaults:8:5']);
+    check('nested literals count their own elements', literals({ 'max-struct-literal-elements': 2, 'max-collection-literal-elements': false }),
+        ['max-struct-literal-elements:defaults:6:3', 'max-struct-literal-elements:defaults:8:5']);
+    check('defaults leave the file alone', literals({}), []);
+    check('only Go is checked', new StructureDetector({ 'max-collection-literal-elements': 1 })
+        .detect('const xs = [1, 2, 3];\nconst m = new Map([[1, 2]]);\n', 'xs.ts').length, 0);
+
+    // Test 6: selects that can block forever
+    console.log('\nTest 6: Select without cancellation');
+    const selects = new StructureDetector().detect(goSelects, 'pump.go')
+        .filter(f => f.ruleId === 'select-without-cancellation');
+    check('only the risky select fires', selects.map(f => `${f.functionName}:${f.startLine}`), ['forward:4']);
+    check('reported at info severity', selects[0]?.severity, 'info');
+    check('select with a default is fine', selects.some(f => f.functionName === 'tryForward'), false);
+    check('select with a ctx.Done() case is fine', selects.some(f => f.functionName === 'forwardUntilDone'), false);
+    check('false disables the rule', new StructureDetector({ 'select-without-cancellation': false })
+        .detect(goSelects, 'pump.go').length, 0);
+    check('true keeps default limits', new StructureDetector({ 'max-parameters': true })
+        .detect(goCode, 'apply.go').find(f => f.ruleId === 'max-parameters')?.threshold, 5);
+
+    // Test 7: imported packages per function
+    console.log('\nTest 7: Imported packages');
+    const packageFindings = (options?: ConstructorParameters<typeof StructureDetector>[1]) => new StructureDetector({}, options)
+        .detect(goImportsCode, 'report.go')
+        .filter(f => f.ruleId === 'max-imported-packages');
+    const publish = packageFindings();
+    check('function touching 8 packages fires', publish.map(f => `${f.functionName}:${f.value}`), ['Publish:8']);
+    console.log(`  ${publish[0]?.message}`);
+    check('signature types are not counted', packageFindings().some(f => f.functionName === 'Name'), false);
+    check('standard library can be left out', new StructureDetector({ 'max-imported-packages': 1 }, { countStandardLibrary: false })
+        .detect(goImportsCode, 'report.go').filter(f => f.ruleId === 'max-imported-packages').map(f => `${f.functionName}:${f.value}`), ['Publish:2']);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testStructureDetector() ? 0 : 1);
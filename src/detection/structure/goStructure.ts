@@ -0,0 +1,428 @@
+/**
+ * Go Structure
+ * Go-specific constructs for the structure rules, read from the token stream
+ *
+ * A composite literal is a type followed by '{' outside a control statement
+ * header (Go requires literals there to be parenthesized), or an elided
+ * '{' element inside another literal. Literals of []T, [N]T and map[K]V
+ * count as collections; any other type counts as a struct, since a named
+ * slice or map type can't be told apart without type information.
+ *
+ * A select can block forever when it has no default and no case receiving
+ * from a Done() channel, a channel named for stopping, or a time.After timeout.
+ *
+ * A function references an imported package when its body selects through
+ * the import's name (pkg.Name). Local variables shadowing an import name
+ * aren't told apart.
+ */
+
+import { Tokenizer } from '../complexity/tokenizer';
+
+/**
+ * Go raw strings, so braces and quotes inside them aren't read as code
+ */
+const RAW_STRING_PATTERN = '`[^`]*`';
+
+/**
+ * Keywords whose statement header runs up to the '{' of their body
+ */
+const HEADER_KEYWORDS = new Set(['if', 'for', 'switch', 'select', 'func']);
+
+const KEYWORDS = new Set([
+    'break', 'case', 'chan', 'const', 'continue', 'default', 'defer', 'else', 'fallthrough', 'for',
+    'func', 'go', 'goto', 'if', 'import', 'interface', 'map', 'package', 'range', 'return', 'select',
+    'struct', 'switch', 'type', 'var'
+]);
+
+/**
+ * Channel names that read as a stop signal: done, quit, stopCh, cancelled, ...
+ */
+const CANCELLATION_CHANNEL = /^(?:done|quit|stop|cancel|shutdown|closing|exit|abort)/i;
+
+export type LiteralKind = 'struct' | 'collection';
+
+/**
+ * A composite literal and the elements directly inside it
+ */
+export interface CompositeLiteral {
+    kind: LiteralKind;
+
+    /** Type as written, or the element type of the enclosing literal when elided */
+    type: string;
+
+    /** Elements of this literal; nested literals count as one element each */
+    elements: number;
+
+    /** Line of the opening brace (0-indexed) */
+    startLine: number;
+
+    /** Line of the closing brace (0-indexed) */
+    endLine: number;
+}
+
+/**
+ * A select statement and how it can stop waiting
+ */
+export interface SelectStatement {
+    /** Has a default clause */
+    hasDefault: boolean;
+
+    /** Has a case receiving from a cancellation channel or timeout */
+    hasCancellation: boolean;
+
+    /** Line of the select keyword (0-indexed) */
+    startLine: number;
+
+    /** Line of the closing brace (0-indexed) */
+    endLine: number;
+}
+
+/**
+ * A code token and the line it starts on
+ */
+export interface GoToken {
+    text: string;
+    line: number;
+}
+
+/**
+ * An open '{' while scanning
+ */
+interface BraceFrame {
+    kind: 'literal' | 'block' | 'type';
+    literal?: CompositeLiteral;
+
+    /** Type of elided literals inside a collection literal */
+    elementType?: string;
+
+    /** Paren/bracket depth at the brace, so only its own commas separate elements */
+    depth: number;
+
+    commas: number;
+
+    /** Whether anything follows the last comma */
+    pending: boolean;
+}
+
+/**
+ * Code tokens of a Go file with their lines
+ */
+export function goTokens(sourceCode: string): GoToken[] {
+    const tokens: GoToken[] = [];
+    let line = 0;
+
+    for (const text of Tokenizer.generateTokens(sourceCode, { additionalPatterns: RAW_STRING_PATTERN })) {
+        if (Tokenizer.isCodeToken(text)) {
+            tokens.push({ text, line });
+        }
+        line += text.split('\n').length - 1;
+    }
+
+    return tokens;
+}
+
+/**
+ * Find every composite literal in a Go file
+ *
+ * @param sourceCode - Full file contents
+ * @returns Literals in the order they close, so nested ones come first
+ */
+export function findCompositeLiterals(sourceCode: string): CompositeLiteral[] {
+    const tokens = goTokens(sourceCode);
+    const literals: CompositeLiteral[] = [];
+    const frames: BraceFrame[] = [];
+    const headers: number[] = [];
+    let depth = 0;
+    let lastClosed: BraceFrame | undefined;
+
+    for (let i = 0; i < tokens.length; i++) {
+        const { text, line } = tokens[i];
+        const top = frames[frames.length - 1];
+        const previous = tokens[i - 1]?.text ?? '';
+
+        if (text === '{') {
+            const frame = openBrace(tokens, i, depth, headers, top, lastClosed);
+            if (frame.kind === 'literal' && top?.kind === 'literal' && depth === top.depth) {
+                top.pending = true;
+            }
+            if (frame.literal) {
+                frame.literal.startLine = line;
+            }
+            frames.push(frame);
+            continue;
+        }
+
+        if (text === '}') {
+            const frame = frames.pop();
+            if (frame?.literal) {
+                frame.literal.elements = frame.commas + (frame.pending ? 1 : 0);
+                frame.literal.endLine = line;
+                literals.push(frame.literal);
+            }
+            lastClosed = frame;
+            continue;
+        }
+
+        if (text === '(' || text === '[') {
+            depth++;
+        } else if (text === ')' || text === ']') {
+            depth--;
+            while (headers.length > 0 && headers[headers.length - 1] > depth) {
+                headers.pop();
+            }
+        } else if (HEADER_KEYWORDS.has(text) && previous !== '.') {
+            headers.push(depth);
+        }
+
+        if (top?.kind === 'literal' && depth >= top.depth) {
+            if (text === ',' && depth === top.depth) {
+                top.commas++;
+                top.pending = false;
+            } else if (text !== ')' && text !== ']') {
+                top.pending = true;
+            }
+        }
+    }
+
+    return literals;
+}
+
+/**
+ * Decide what a '{' opens: a statement body, a struct/interface type body, or a literal
+ */
+function openBrace(
+    tokens: GoToken[],
+    index: number,
+    depth: number,
+    headers: number[],
+    enclosing: BraceFrame | undefined,
+    lastClosed: BraceFrame | undefined
+): BraceFrame {
+    const previous = tokens[index - 1]?.text ?? '';
+    const frame = (kind: BraceFrame['kind'], type?: string): BraceFrame => {
+        const literal = type === undefined ? undefined : { kind: literalKind(type), type, elements: 0, startLine: 0, endLine: 0 };
+        return { kind, literal, elementType: type === undefined ? undefined : elementType(type), depth, commas: 0, pending: false };
+    };
+
+    if (headers.length > 0 && headers[headers.length - 1] === depth) {
+        headers.pop();
+        return frame('block');
+    }
+    if (previous === 'struct' || previous === 'interface') {
+        return frame('type');
+    }
+
+    // Elided element type: []Point{{1, 2}, {3, 4}}
+    if (enclosing?.kind === 'literal' && depth === enclosing.depth && ['{', ',', ':'].includes(previous)) {
+        return frame('literal', enclosing.elementType ?? '');
+    }
+
+    // Anonymous struct type: struct{ X int }{X: 1}
+    if (previous === '}' && lastClosed?.kind === 'type') {
+        return frame('literal', 'struct{...}');
+    }
+
+    const type = literalType(tokens, index);
+    return type === undefined ? frame('block') : frame('literal', type);
+}
+
+/**
+ * The type written before a literal's '{', or undefined when the brace doesn't follow a type
+ */
+function literalType(tokens: GoToken[], braceIndex: number): string | undefined {
+    const parts: string[] = [];
+    let i = braceIndex - 1;
+
+    const last = tokens[i]?.text ?? '';
+    if (last !== ']' && (!/^[A-Za-z_]\w*$/.test(last) || KEYWORDS.has(last))) {
+        return undefined;
+    }
+
+    while (i >= 0) {
+        const text = tokens[i].text;
+        if (text === ']') {
+            // Take the whole [..] group: [], [N], [...], map keys and type arguments
+            let nesting = 0;
+            let j = i;
+            for (; j >= 0; j--) {
+                if (tokens[j].text === ']') {
+                    nesting++;
+                } else if (tokens[j].text === '[') {
+                    nesting--;
+                    if (nesting === 0) {
+                        break;
+                    }
+                }
+            }
+            if (j < 0) {
+                return undefined;
+            }
+            parts.unshift(...tokens.slice(j, i + 1).map(token => token.text));
+            i = j - 1;
+        } else if (/^[A-Za-z_]\w*$/.test(text) && (!KEYWORDS.has(text) || text === 'map')) {
+            parts.unshift(text);
+            i--;
+            // Only a qualifier, a bracket group or '*' can come before a type name
+            if (tokens[i]?.text !== '.' && tokens[i]?.text !== ']' && tokens[i]?.text !== '*') {
+                break;
+            }
+        } else if (text === '.' || text === '*') {
+            parts.unshift(text);
+            i--;
+        } else {
+            break;
+        }
+    }
+
+    // A leading '*' belongs to the expression (*p{...} isn't a literal)
+    const type = parts.join('');
+    return type && !type.startsWith('*') && !type.startsWith('.') ? type : undefined;
+}
+
+function literalKind(type: string): LiteralKind {
+    return type.startsWith('[') || type.startsWith('map[') ? 'collection' : 'struct';
+}
+
+/**
+ * Type of a collection's elements (map values), for literals that elide it
+ */
+function elementType(type: string): string | undefined {
+    const start = type.startsWith('map[') ? 3 : type.startsWith('[') ? 0 : -1;
+    if (start < 0) {
+        return undefined;
+    }
+
+    let nesting = 0;
+    for (let i = start; i < type.length; i++) {
+        if (type[i] === '[') {
+            nesting++;
+        } else if (type[i] === ']' && --nesting === 0) {
+            // []*Point{{...}} elides &Point
+            return type.slice(i + 1).replace(/^\*/, '');
+        }
+    }
+    return undefined;
+}
+
+/**
+ * Find every select statement in a Go file
+ *
+ * @param sourceCode - Full file contents
+ * @returns Selects in the order they close, so nested ones come first
+ */
+export function findSelects(sourceCode: string): SelectStatement[] {
+    const tokens = goTokens(sourceCode);
+    const selects: SelectStatement[] = [];
+    const open: { statement: SelectStatement; braceDepth: number }[] = [];
+    let braceDepth = 0;
+
+    for (let i = 0; i < tokens.length; i++) {
+        const { text, line } = tokens[i];
+        const current = open[open.length - 1];
+
+        if (text === 'select' && tokens[i + 1]?.text === '{' && tokens[i - 1]?.text !== '.') {
+            open.push({ statement: { hasDefault: false, hasCancellation: false, startLine: line, endLine: line }, braceDepth: braceDepth + 1 });
+        } else if (text === '{') {
+            braceDepth++;
+        } else if (text === '}') {
+            if (current && current.braceDepth === braceDepth) {
+                current.statement.endLine = line;
+                selects.push(current.statement);
+                open.pop();
+            }
+            braceDepth--;
+        } else if (current && current.braceDepth === braceDepth) {
+            if (text === 'default' && tokens[i + 1]?.text === ':') {
+                current.statement.hasDefault = true;
+            } else if (text === 'case' && isCancellationCase(caseClause(tokens, i + 1))) {
+                current.statement.hasCancellation = true;
+            }
+        }
+    }
+
+    return selects;
+}
+
+/**
+ * Tokens of a case clause's communication, up to its ':'
+ */
+function caseClause(tokens: GoToken[], start: number): string[] {
+    const clause: string[] = [];
+    let depth = 0;
+
+    for (let i = start; i < tokens.length; i++) {
+        const text = tokens[i].text;
+        if (text === '(' || text === '[') {
+            depth++;
+        } else if (text === ')' || text === ']') {
+            depth--;
+        } else if (text === ':' && depth === 0 && tokens[i + 1]?.text !== '=') {
+            break;
+        }
+        clause.push(text);
+    }
+
+    return clause;
+}
+
+/**
+ * Whether a case receives from ctx.Done(), a stop channel or time.After
+ */
+function isCancellationCase(clause: string[]): boolean {
+    const receive = clause.findIndex((text, i) => text === '<' && clause[i + 1] === '-');
+    if (receive < 0) {
+        return false;
+    }
+
+    // Only the channel expression itself, not index or call arguments
+    const channel: string[] = [];
+    let depth = 0;
+    for (const text of clause.slice(receive + 2)) {
+        if (text === ')' || text === ']') {
+            depth--;
+        }
+        channel.push(depth === 0 ? text : '');
+        if (text === '(' || text === '[') {
+            depth++;
+        }
+    }
+
+    return channel.some((text, i) =>
+        (text === 'Done' && channel[i + 1] === '(') ||
+        (text === 'After' && channel[i - 1] === '.' && channel[i - 2] === 'time') ||
+        (/^[A-Za-z_]\w*$/.test(text) && channel[i + 1] !== '(' && CANCELLATION_CHANNEL.test(text)));
+}
+
+/**
+ * Import paths a function body selects from
+ *
+ * @param tokens - Tokens of the function, signature included
+ * @param imports - The file's imports by local name
+ */
+export function referencedImports(tokens: GoToken[], imports: Map<string, string>): Set<string> {
+    const referenced = new Set<string>();
+
+    // Parameter and result types aren't the body
+    let depth = 0;
+    let bodyStart = tokens.length;
+    for (let i = 0; i < tokens.length; i++) {
+        const text = tokens[i].text;
+        if (text === '(' || text === '[') {
+            depth++;
+        } else if (text === ')' || text === ']') {
+            depth--;
+        } else if (text === '{' && depth === 0 && tokens[i - 1]?.text !== 'struct' && tokens[i - 1]?.text !== 'interface') {
+            bodyStart = i + 1;
+            break;
+        }
+    }
+
+    for (let i = bodyStart; i < tokens.length - 1; i++) {
+        const importPath = imports.get(tokens[i].text);
+        if (importPath !== undefined && tokens[i + 1].text === '.' && tokens[i - 1]?.text !== '.') {
+            referenced.add(importPath);
+        }
+    }
+
+    return referenced;
+}
@@ -0,0 +1,22 @@
+/**
+ * Structure Module
+ *
+ * Nesting depth, parameter count and length rules, plus Go composite
+ * literal, select and imported package rules, each with its own rule ID
+ * and threshold
+ *
+ * @module structure
+ */
+
+export { StructureDetector, DEFAULT_RULE_THRESHOLDS } from './structureDetector';
+export { findCompositeLiterals, findSelects, referencedImports } from './goStructure';
+
+export type {
+    StructureRuleId,
+    StructureRuleThresholds,
+    StructureFinding,
+    StructureOptions,
+    StructureSeverity
+} from './types';
+
+export type { CompositeLiteral, LiteralKind, SelectStatement } from './goStructure';
@@ -0,0 +1,324 @@
+/**
+ * Structure Detector
+ * Flags functions and files that are too deep, too wide or too long
+ *
+ * Rules (each with its own threshold, false disables it):
+ * - max-nesting-depth: control structures and closures nested inside a function body
+ * - max-parameters: parameters in a function signature
+ * - max-function-lines: lines from signature to closing brace, blanks included
+ * - max-function-statements: code lines in the body, plus extra statements joined with ';'
+ * - max-file-lines: lines in the file
+ * - max-struct-literal-elements: fields in one Go struct literal
+ * - max-collection-literal-elements: elements in one Go slice, array or map literal
+ *
+ * Nested literals are checked on their own and count as one element of their parent.
+ *
+ * - select-without-cancellation: Go select with no default and no case receiving
+ *   from a Done() or stop channel or a timeout, reported at info severity
+ * - max-imported-packages: distinct Go imports a function body selects from;
+ *   standard library packages count unless countStandardLibrary is false
+ */
+
+import { ComplexityAnalyzer } from '../complexity/analyzer';
+import { CognitiveComplexityCalculator } from '../complexity/cognitiveComplexity';
+import { Tokenizer } from '../complexity/tokenizer';
+import { FunctionInfo, Language } from '../complexity/types';
+import { goImports, isStandardLibrary } from '../../utils/goImports';
+import { findCompositeLiterals, findSelects, goTokens, referencedImports } from './goStructure';
+import { StructureFinding, StructureOptions, StructureRuleId, StructureRuleThresholds } from './types';
+
+/**
+ * Default limits, in line with common linter presets
+ */
+export const DEFAULT_RULE_THRESHOLDS: StructureRuleThresholds = {
+    'max-nesting-depth': 4,
+    'max-parameters': 5,
+    'max-function-lines': 60,
+    'max-function-statements': 40,
+    'max-file-lines': 500,
+    'max-struct-literal-elements': 12,
+    'max-collection-literal-elements': 50,
+    'select-without-cancellation': true,
+    'max-imported-packages': 6
+};
+
+/**
+ * Per-function structural measurements
+ */
+export interface FunctionStructure {
+    nestingDepth: number;
+    parameterCount: number;
+    lineCount: number;
+    statementCount: number;
+}
+
+export class StructureDetector {
+    private thresholds: StructureRuleThresholds;
+    private options: StructureOptions;
+
+    constructor(thresholds: Partial<StructureRuleThresholds> = {}, options: StructureOptions = {}) {
+        this.thresholds = { ...DEFAULT_RULE_THRESHOLDS, ...thresholds };
+        this.options = options;
+
+        for (const ruleId of Object.keys(this.thresholds) as StructureRuleId[]) {
+            if (this.thresholds[ruleId] === true) {
+                this.thresholds[ruleId] = DEFAULT_RULE_THRESHOLDS[ruleId];
+            }
+        }
+    }
+
+    /**
+     * Check a file against every enabled rule
+     *
+     * @param sourceCode - Full file contents
+     * @param filename - File name for language detection
+     * @returns Findings ordered by line
+     */
+    detect(sourceCode: string, filename: string): StructureFinding[] {
+        const analyzer = ComplexityAnalyzer.forFile(filename);
+        const language = analyzer.getLanguage();
+        const lines = sourceCode.split('\n');
+        const findings: StructureFinding[] = [];
+
+        const fileLines = sourceCode.endsWith('\n') ? lines.length - 1 : lines.length;
+        this.check(findings, 'max-file-lines', fileLines, undefined, 0, lines.length - 1,
+            (value, limit) => `File has ${value} lines (limit ${limit})`);
+
+        const functions = analyzer.analyze(sourceCode).functions.filter(func => func.name !== '*global*');
+        for (const func of functions) {
+
+            const structure = StructureDetector.measure(func, lines, language);
+            const { name, startLine, endLine } = func;
+
+            this.check(findings, 'max-nesting-depth', structure.nestingDepth, name, startLine, endLine,
+                (value, limit) => `${name} nests ${value} levels deep (limit ${limit})`);
+            this.check(findings, 'max-parameters', structure.parameterCount, name, startLine, endLine,
+                (value, limit) => `${name} takes ${value} parameters (limit ${limit})`);
+            this.check(findings, 'max-function-lines', structure.lineCount, name, startLine, endLine,
+                (value, limit) => `${name} is ${value} lines long (limit ${limit})`);
+            this.check(findings, 'max-function-statements', structure.statementCount, name, startLine, endLine,
+                (value, limit) => `${name} has ${value} statements (limit ${limit})`);
+        }
+
+        if (language === Language.Go) {
+            this.checkLiterals(findings, sourceCode, functions);
+            this.checkSelects(findings, sourceCode, functions);
+            this.checkImports(findings, sourceCode, functions);
+        }
+
+        return findings.sort((a, b) => a.startLine - b.startLine);
+    }
+
+    /**
+     * Measure a function's structure
+     *
+     * @param func - Function from the complexity analyzer
+     * @param lines - Source lines of the whole file
+     * @param language - Source language
+     */
+    static measure(func: FunctionInfo, lines: string[], language: Language): FunctionStructure {
+        const bodyLines = lines.slice(func.startLine, func.endLine + 1);
+
+        return {
+            nestingDepth: language === Language.Python
+                ? this.indentationDepth(bodyLines)
+                : this.braceDepth(bodyLines.join('\n'), language),
+            parameterCount: func.parameterCount,
+            lineCount: func.endLine - func.startLine + 1,
+            statementCount: this.countStatements(bodyLines.slice(1))
+        };
+    }
+
+    private check(
+        findings: StructureFinding[],
+        ruleId: StructureRuleId,
+        value: number,
+        functionName: string | undefined,
+        startLine: number,
+        endLine: number,
+        describe: (value: number, limit: number) => string
+    ): void {
+        const limit = this.thresholds[ruleId];
+        if (typeof limit !== 'number' || value <= limit) {
+            return;
+        }
+
+        findings.push({
+            ruleId,
+            message: describe(value, limit),
+            functionName,
+            startLine,
+            endLine,
+            value,
+            threshold: limit
+        });
+    }
+
+    /**
+     * Composite literal sizes, reported against the innermost enclosing function
+     */
+    private checkLiterals(findings: StructureFinding[], sourceCode: string, functions: FunctionInfo[]): void {
+        for (const literal of findCompositeLiterals(sourceCode)) {
+            const owner = StructureDetector.enclosingFunction(functions, literal.startLine, literal.endLine);
+            const ruleId = literal.kind === 'struct' ? 'max-struct-literal-elements' : 'max-collection-literal-elements';
+            const type = literal.type || 'Composite';
+
+            this.check(findings, ruleId, literal.elements, owner?.name, literal.startLine, literal.endLine,
+                (value, limit) => `${type} literal has ${value} elements (limit ${limit}); consider extracting it to a named variable or builder`);
+        }
+    }
+
+    /**
+     * Selects that can block forever
+     */
+    private checkSelects(findings: StructureFinding[], sourceCode: string, functions: FunctionInfo[]): void {
+        if (this.thresholds['select-without-cancellation'] === false) {
+            return;
+        }
+
+        for (const select of findSelects(sourceCode)) {
+            if (select.hasDefault || select.hasCancellation) {
+                continue;
+            }
+
+            const owner = StructureDetector.enclosingFunction(functions, select.startLine, select.endLine);
+            findings.push({
+                ruleId: 'select-without-cancellation',
+                message: `select in ${owner?.name ?? 'package scope'} has no default or cancellation case and can block forever; consider a ctx.Done() case`,
+                functionName: owner?.name,
+                startLine: select.startLine,
+                endLine: select.endLine,
+                value: 1,
+                threshold: 0,
+                severity: 'info'
+            });
+        }
+    }
+
+    /**
+     * Imported packages referenced per function
+     */
+    private checkImports(findings: StructureFinding[], sourceCode: string, functions: FunctionInfo[]): void {
+        const imports = goImports(sourceCode);
+        if (this.options.countStandardLibrary === false) {
+            for (const [name, importPath] of imports) {
+                if (isStandardLibrary(importPath)) {
+                    imports.delete(name);
+                }
+            }
+        }
+        if (imports.size === 0) {
+            return;
+        }
+
+        const tokens = goTokens(sourceCode);
+        for (const { name, startLine, endLine } of functions) {
+            const body = tokens.filter(token => token.line >= startLine && token.line <= endLine);
+            const packages = referencedImports(body, imports);
+
+            this.check(findings, 'max-imported-packages', packages.size, name, startLine, endLine,
+                (value, limit) => `${name} uses ${value} imported packages (limit ${limit}): ${[...packages].sort().join(', ')}`);
+        }
+    }
+
+    /**
+     * Innermost function spanning the given lines
+     */
+    private static enclosingFunction(functions: FunctionInfo[], startLine: number, endLine: number): FunctionInfo | undefined {
+        return functions
+            .filter(func => func.startLine <= startLine && endLine <= func.endLine)
+            .sort((a, b) => b.startLine - a.startLine)[0];
+    }
+
+    /**
+     * Nesting inside the function body for brace languages
+     * Skips the signature so the body's own braces are depth 0
+     */
+    private static braceDepth(source: string, language: Language): number {
+        const tokens = Tokenizer.filterCodeTokens(Tokenizer.generateTokens(source));
+
+        let parenDepth = 0;
+        let bodyStart = -1;
+        for (let i = 0; i < tokens.length; i++) {
+            if (tokens[i] === '(') {
+                parenDepth++;
+            } else if (tokens[i] === ')') {
+                parenDepth--;
+            } else if (tokens[i] === '{' && parenDepth === 0) {
+                bodyStart = i + 1;
+                break;
+            }
+        }
+
+        if (bodyStart < 0) {
+            // Expression-bodied arrow function
+            return 0;
+        }
+
+        return new CognitiveComplexityCalculator(language).maxNesting(tokens.slice(bodyStart));
+    }
+
+    /**
+     * Nesting inside the function body for Python, from indentation
+     */
+    private static indentationDepth(bodyLines: string[]): number {
+        const indents: number[] = [];
+        let maxDepth = 0;
+
+        for (const line of bodyLines.slice(1)) {
+            const trimmed = line.trim();
+            if (!trimmed || trimmed.startsWith('#')) {
+                continue;
+            }
+
+            const indent = line.length - line.trimStart().length;
+            while (indents.length > 0 && indent < indents[indents.length - 1]) {
+                indents.pop();
+            }
+            if (indents.length === 0 || indent > indents[indents.length - 1]) {
+                indents.push(indent);
+            }
+
+            // First level is the body itself
+            maxDepth = Math.max(maxDepth, indents.length - 1);
+        }
+
+        return maxDepth;
+    }
+
+    /**
+     * Code lines in the body that contain more than brackets, plus
+     * any additional statements joined on one line with ';'
+     */
+    private static countStatements(bodyLines: string[]): number {
+        let statements = 0;
+
+        for (const line of bodyLines) {
+            const tokens = Tokenizer.filterCodeTokens(Tokenizer.generateTokens(line));
+            if (!tokens.some(token => /^\w|^["'`]/.test(token))) {
+                continue;
+            }
+
+            statements++;
+
+            // Go's for clause has no parentheses: 'for i := 0; i < n; i++' is one statement
+            if (tokens[0] === 'for') {
+                continue;
+            }
+
+            // 'a(); b()' is two statements; 'for (i = 0; i < n; i++)' is one
+            let parenDepth = 0;
+            for (let i = 0; i < tokens.length - 1; i++) {
+                if (tokens[i] === '(') {
+                    parenDepth++;
+                } else if (tokens[i] === ')') {
+                    parenDepth--;
+                } else if (tokens[i] === ';' && parenDepth === 0 && tokens[i + 1] !== '}') {
+                    statements++;
+                }
+            }
+        }
+
+        return statements;
+    }
+}
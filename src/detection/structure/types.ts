@@ -0,0 +1,65 @@
+/**
+ * Type definitions for structural rules
+ */
+
+/**
+ * Rule identifiers, used in settings and reported on every finding
+ */
+export type StructureRuleId =
+    | 'max-nesting-depth'
+    | 'max-parameters'
+    | 'max-function-lines'
+    | 'max-function-statements'
+    | 'max-file-lines'
+    | 'max-struct-literal-elements'
+    | 'max-collection-literal-elements'
+    | 'select-without-cancellation'
+    | 'max-imported-packages';
+
+/**
+ * Limit per rule; false disables the rule, true enables it with its default limit
+ * Rules without a limit (select-without-cancellation) only take true or false
+ */
+export type StructureRuleThresholds = Record<StructureRuleId, number | boolean>;
+
+/**
+ * Settings that change how rules measure, rather than their limits
+ */
+export interface StructureOptions {
+    /** Count standard library packages toward max-imported-packages (default true) */
+    countStandardLibrary?: boolean;
+}
+
+/**
+ * Severity a rule reports at unless voight.ruleSeverities overrides it
+ */
+export type StructureSeverity = 'error' | 'warning' | 'info';
+
+/**
+ * A rule violation
+ */
+export interface StructureFinding {
+    /** Rule that fired */
+    ruleId: StructureRuleId;
+
+    /** Human-readable description */
+    message: string;
+
+    /** Offending function (undefined for file-level rules) */
+    functionName?: string;
+
+    /** Starting line (0-indexed) */
+    startLine: number;
+
+    /** Ending line (0-indexed, inclusive) */
+    endLine: number;
+
+    /** Measured value */
+    value: number;
+
+    /** Limit that was exceeded (0 for rules without a limit) */
+    threshold: number;
+
+    /** Default severity, warning when unset */
+    severity?: StructureSeverity;
+}
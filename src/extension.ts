@@ -1,5 +1,6 @@
 // The module 'vscode' contains the VS Code extensibility API
 import * as vscode from 'vscode';
+import * as path from 'path';
 import { Logger } from './utils/logger';
 import { healthCheck } from './commands/health';
 import { createDefaultHighlighter } from './ui/highlighter';
@@ -10,11 +11,27 @@ import { DetectionCoordinator } from './detection/detectionCoordinator';
 import { DebugLogger } from './debug/debugLogger';
 import { ContextNotesManager } from './ui/contextNotes';
 import { SegmentsWebviewProvider } from './ui/segmentsWebviewProvider';
+import { ComplexityCodeLensProvider, COMPLEXITY_LANGUAGES } from './ui/complexityCodeLensProvider';
+import { StructureDiagnostics } from './ui/structureDiagnostics';
 import { VscodeSegmentRepository } from './storage/VscodeSegmentRepository';
 import { FileTrackingService } from './tracking/fileTracker';
 import { FileRegistry } from './tracking/fileRegistry';
 import { ShadowMetadataManager } from './tracking/shadowMetadataManager';
 import { ShadowGarbageCollector } from './tracking/shadowGarbageCollector';
+import { LikelihoodDetector } from './detection/likelihood';
+import { CloneDetector } from './detection/clones';
+import { HotspotRanker, HotspotInput, readGitChurn } from './detection/hotspots';
+import { GrowthEstimator } from './detection/growth';
+import { CallGraphBuilder } from './detection/callgraph';
+import { PackageGraphBuilder } from './detection/coupling';
+import { AnnotationVerifier, AnnotationCheck } from './detection/annotations';
+import { RevisionComparer, RevisionComparison, compareRevisions, resolveRef } from './detection/compare';
+import { ComplexityThresholds } from './utils/complexityThresholds';
+import { ComplexityAnalyzer } from './detection/complexity/analyzer';
+import { ComplexityScorer } from './detection/complexity/scorer';
+import { FilePatternMatcher } from './utils/filePatternMatcher';
+import { EXCLUDED_GLOB, SOURCE_EXTENSION, isInExcludedDirectory } from './utils/sourcePaths';
+import { readWorkspaceSources } from './utils/workspaceSources';
 
 let coordinator: DetectionCoordinator;
 let blockManager: BlockManager;
@@ -113,6 +130,21 @@ export async function activate(context: vscode.ExtensionContext) {
 		)
 	);
 
+	// Register complexity code lenses above each function
+	const complexityCodeLensProvider = new ComplexityCodeLensProvider();
+	context.subscriptions.push(
+		complexityCodeLensProvider,
+		vscode.languages.registerCodeLensProvider(COMPLEXITY_LANGUAGES, complexityCodeLensProvider)
+	);
+
+	// Per-path complexity levels and structure rule limits, shared by every consumer
+	const complexityThresholds = new ComplexityThresholds();
+	context.subscriptions.push(complexityThresholds);
+
+	// Structure rule findings (nesting, parameters, length) shown as diagnostics
+	const structureDiagnostics = new StructureDiagnostics(complexityThresholds);
+	context.subscriptions.push(structureDiagnostics);
+
 	// Register action plugins
 	blockManager.highlighter.registerActionPlugin(new ReviewPlugin());
 	blockManager.highlighter.registerActionPlugin(new AnalyzePlugin());
@@ -131,7 +163,7 @@ export async function activate(context: vscode.ExtensionContext) {
 	Logger.debug('Shadow metadata manager initialized');
 
 	// Initialize detection coordinator with file registry, UI and optional debug integration
-	coordinator = new DetectionCoordinator(fileRegistry, blockManager, debugLogger);
+	coordinator = new DetectionCoordinator(fileRegistry, complexityThresholds, blockManager, debugLogger);
 
 	// Connect metadata manager to change detector
 	coordinator.getChangeDetector().setMetadataManager(shadowMetadataManager);
@@ -411,6 +443,591 @@ Last edited: ${new Date(fileData.lastEditedAt).toLocaleString()}`;
 		vscode.window.showInformationMessage(message);
 	});
 
+	// Assess how likely the current file is to be machine-generated
+	const assessAiLikelihoodCommand = vscode.commands.registerCommand('voight.assessAiLikelihood', async () => {
+		const activeEditor = vscode.window.activeTextEditor;
+		if (!activeEditor) {
+			vscode.window.showInformationMessage('No active file');
+			return;
+		}
+
+		const document = activeEditor.document;
+		const fileData = await fileTrackingService.getFileData(document.fileName);
+		const provenance = fileData
+			? { aiLinesChanged: fileData.totalLinesChanged, aiEditCount: fileData.editCount }
+			: undefined;
+
+		const result = LikelihoodDetector.assess(document.getText(), document.fileName, provenance);
+		const evidence = result.fired.length > 0
+			? result.fired.map(s => `• ${s.label}: ${s.detail}`).join('\n')
+			: 'No signals fired';
+
+		const message = `${vscode.workspace.asRelativePath(document.fileName)}:
+Machine-generated: ${result.level} (${Math.round(result.probability * 100)}%)
+${evidence}`;
+
+		vscode.window.showInformationMessage(message);
+	});
+
+	// Workspace-wide commands skip generated and vendored code like live detection does
+	const workspaceFileFilter = new FilePatternMatcher();
+
+	// Find near-duplicate functions across the workspace
+	const findClonesCommand = vscode.commands.registerCommand('voight.findClones', async () => {
+		const cloneConfig = vscode.workspace.getConfiguration('voight.clones');
+		const detector = new CloneDetector({
+			minTokens: cloneConfig.get<number>('minTokens', 50),
+			minSimilarity: cloneConfig.get<number>('minSimilarity', 0.85)
+		});
+
+		const classes = await vscode.window.withProgress({
+			location: vscode.ProgressLocation.Notification,
+			title: 'Voight: Searching for duplicate functions...'
+		}, async () => {
+			for (const { uri, content } of await readWorkspaceSources(workspaceFileFilter, 'Clone detection')) {
+				detector.addFile(uri.fsPath, content);
+			}
+
+			return detector.detect();
+		});
+
+		if (classes.length === 0) {
+			vscode.window.showInformationMessage('No duplicate functions found');
+			return;
+		}
+
+		// Display clone classes, then the members of the selected class
+		const classItems = classes.map((cloneClass, index) => ({
+			label: `#${index + 1} - ${cloneClass.fragments.length} copies of ~${cloneClass.tokenCount} tokens`,
+			description: `${Math.round(cloneClass.similarity * 100)}% similar`,
+			detail: cloneClass.fragments.map(f => f.functionName).join(', '),
+			cloneClass
+		}));
+
+		const selectedClass = await vscode.window.showQuickPick(classItems, {
+			placeHolder: `${classes.length} clone class${classes.length > 1 ? 'es' : ''} found`
+		});
+
+		if (!selectedClass) {
+			return;
+		}
+
+		const fragmentItems = selectedClass.cloneClass.fragments.map(fragment => ({
+			label: fragment.functionName,
+			description: `${vscode.workspace.asRelativePath(fragment.filePath)}:${fragment.startLine + 1}`,
+			fragment
+		}));
+
+		const selectedFragment = await vscode.window.showQuickPick(fragmentItems, {
+			placeHolder: 'Select a copy to open'
+		});
+
+		if (selectedFragment) {
+			const { fragment } = selectedFragment;
+			const doc = await vscode.workspace.openTextDocument(fragment.filePath);
+			const editor = await vscode.window.showTextDocument(doc);
+			const range = new vscode.Range(fragment.startLine, 0, fragment.endLine, Number.MAX_VALUE);
+			editor.selection = new vscode.Selection(range.start, range.end);
+			editor.revealRange(range, vscode.TextEditorRevealType.InCenter);
+		}
+	});
+
+	// Rank files by git churn x complexity
+	const showHotspotsCommand = vscode.commands.registerCommand('voight.showHotspots', async () => {
+		const days = vscode.workspace.getConfiguration('voight.hotspots').get<number>('days', 180);
+
+		let churn: Map<string, number>;
+		try {
+			churn = await readGitChurn(workspaceRoot, days);
+		} catch (error) {
+			Logger.warn(`Hotspot analysis could not read git history: ${error}`);
+			vscode.window.showWarningMessage('Hotspots need a git repository - could not read git log');
+			return;
+		}
+
+		const hotspots = await vscode.window.withProgress({
+			location: vscode.ProgressLocation.Notification,
+			title: 'Voight: Ranking hotspots...'
+		}, async () => {
+			// Only the most changed source files can rank high, so analyze those
+			const candidates = [...churn.entries()]
+				.filter(([file]) => SOURCE_EXTENSION.test(file) && !isInExcludedDirectory(file))
+				.sort((a, b) => b[1] - a[1])
+				.slice(0, 200);
+
+			const inputs: HotspotInput[] = [];
+			for (const [file, commits] of candidates) {
+				const uri = vscode.Uri.joinPath(vscode.Uri.file(workspaceRoot), file);
+				try {
+					const content = Buffer.from(await vscode.workspace.fs.readFile(uri)).toString('utf8');
+					if (!workspaceFileFilter.isExcludedGenerated(uri.fsPath, content)) {
+						inputs.push({ filePath: uri.fsPath, churn: commits, sourceCode: content });
+					}
+				} catch {
+					// Deleted since it was last committed
+				}
+			}
+
+			return HotspotRanker.rank(inputs);
+		});
+
+		if (hotspots.length === 0) {
+			vscode.window.showInformationMessage(`No source files changed in the last ${days} days`);
+			return;
+		}
+
+		// Display in quick pick
+		const items = hotspots.slice(0, 50).map(file => ({
+			label: `#${file.rank} - ${vscode.workspace.asRelativePath(file.filePath)}`,
+			description: `score ${file.score.toFixed(2)} · ${file.churn} commits · cognitive ${file.cognitiveComplexity} · ${file.nloc} NLOC`,
+			detail: file.functions.length > 0
+				? `Hottest: ${file.functions.slice(0, 3).map(f => `${f.functionName} (${f.cognitiveComplexity})`).join(', ')}`
+				: undefined,
+			file
+		}));
+
+		const selected = await vscode.window.showQuickPick(items, {
+			placeHolder: `Hotspots over the last ${days} days (churn x complexity)`
+		});
+
+		if (selected) {
+			// Open the file at its hottest function
+			const doc = await vscode.workspace.openTextDocument(selected.file.filePath);
+			const editor = await vscode.window.showTextDocument(doc);
+			const hottest = selected.file.functions[0];
+			if (hottest) {
+				const position = new vscode.Position(hottest.startLine, 0);
+				editor.selection = new vscode.Selection(position, position);
+				editor.revealRange(new vscode.Range(position, position), vscode.TextEditorRevealType.InCenter);
+			}
+		}
+	});
+
+	// Check the current file against the structure rules
+	const checkStructureCommand = vscode.commands.registerCommand('voight.checkStructure', () => {
+		const activeEditor = vscode.window.activeTextEditor;
+		if (!activeEditor) {
+			vscode.window.showInformationMessage('No active file');
+			return;
+		}
+
+		const findings = structureDiagnostics.check(activeEditor.document);
+		if (findings.length === 0) {
+			vscode.window.showInformationMessage('No structure rule violations in this file');
+			return;
+		}
+
+		const byRule = new Map<string, number>();
+		for (const finding of findings) {
+			byRule.set(finding.ruleId, (byRule.get(finding.ruleId) || 0) + 1);
+		}
+		const summary = [...byRule.entries()].map(([rule, count]) => `${rule}: ${count}`).join(', ');
+		vscode.window.showInformationMessage(`${findings.length} structure finding${findings.length > 1 ? 's' : ''} (${summary}) - see the Problems panel`);
+	});
+
+	// Estimate Big-O for each function in the current file
+	const estimateGrowthCommand = vscode.commands.registerCommand('voight.estimateGrowth', async () => {
+		const activeEditor = vscode.window.activeTextEditor;
+		if (!activeEditor) {
+			vscode.window.showInformationMessage('No active file');
+			return;
+		}
+
+		const document = activeEditor.document;
+		const estimates = GrowthEstimator.estimate(document.getText(), document.fileName);
+		if (estimates.length === 0) {
+			vscode.window.showInformationMessage('No functions found in this file');
+			return;
+		}
+
+		// Display in quick pick, fastest growing first, then in file order
+		const items = [...estimates]
+			.sort((a, b) => (b.degree - a.degree) || (a.startLine - b.startLine))
+			.map(estimate => ({
+				label: `${estimate.notation} - ${estimate.functionName}`,
+				description: `line ${estimate.startLine + 1}`,
+				detail: estimate.reasons.length > 0
+					? estimate.reasons.map(r => `line ${r.line + 1}: ${r.description}`).join(' · ')
+					: estimate.summary,
+				estimate
+			}));
+
+		const selected = await vscode.window.showQuickPick(items, {
+			placeHolder: 'Estimated complexity (heuristic)',
+			matchOnDetail: true
+		});
+
+		if (selected) {
+			const position = new vscode.Position(selected.estimate.startLine, 0);
+			activeEditor.selection = new vscode.Selection(position, position);
+			activeEditor.revealRange(new vscode.Range(position, position), vscode.TextEditorRevealType.InCenter);
+		}
+	});
+
+	// Explain a function's complexity score: the CCN decision points and size that set its
+	// level, then the constructs behind its cognitive complexity. Runs on the function at
+	// the cursor, or on a given line when invoked from a code lens
+	const explainComplexityCommand = vscode.commands.registerCommand('voight.explainComplexity', async (uri?: vscode.Uri, line?: number) => {
+		const activeEditor = uri
+			? await vscode.window.showTextDocument(uri)
+			: vscode.window.activeTextEditor;
+		if (!activeEditor) {
+			vscode.window.showInformationMessage('No active file');
+			return;
+		}
+
+		const document = activeEditor.document;
+		const targetLine = line ?? activeEditor.selection.active.line;
+		const func = ComplexityAnalyzer.forFile(document.fileName).analyze(document.getText()).functions
+			.filter(f => f.name !== '*global*' && f.startLine <= targetLine && f.endLine >= targetLine)
+			.sort((a, b) => b.startLine - a.startLine)[0];
+		if (!func) {
+			vscode.window.showInformationMessage('Place the cursor inside a function to explain its complexity');
+			return;
+		}
+
+		// The score and level come from CCN and NLOC only; cognitive complexity is shown, not scored
+		const scored = ComplexityScorer.scoreFunction(func);
+		const { weights } = ComplexityScorer.getConfig();
+		const level = ComplexityScorer.getComplexityLevel(scored.score, complexityThresholds.forFile(document.fileName));
+		const sourceLine = (n: number) => document.lineAt(Math.min(n, document.lineCount - 1)).text.trim();
+
+		type ExplainItem = vscode.QuickPickItem & { line?: number };
+		const items: ExplainItem[] = [
+			{ label: 'Score', kind: vscode.QuickPickItemKind.Separator },
+			{
+				label: `${level}: score ${scored.score}/10`,
+				detail: `round(${scored.breakdown.ccnScore} × ${weights.ccn} + ${scored.breakdown.sizeScore} × ${weights.size}), clamped to 1-10`
+			},
+			{ label: `CCN ${func.cyclomaticComplexity} → ${scored.breakdown.ccnScore}/10`, description: `weight ${weights.ccn}` },
+			{ label: `${func.nloc} lines of code → ${scored.breakdown.sizeScore}/10`, description: `weight ${weights.size}` },
+			{ label: `CCN ${func.cyclomaticComplexity}: 1 + decision points`, kind: vscode.QuickPickItemKind.Separator }
+		];
+
+		// One entry per construct and line, so repeated operators on a line read as one +n
+		const points = new Map<string, { line: number; construct: string; count: number }>();
+		for (const point of func.decisionPoints || []) {
+			const key = `${point.line}:${point.construct}`;
+			const entry = points.get(key) || { line: point.line, construct: point.construct, count: 0 };
+			entry.count++;
+			points.set(key, entry);
+		}
+		if (points.size === 0) {
+			items.push({ label: 'No decision points', detail: 'Straight-line code has the base CCN of 1' });
+		}
+		for (const point of points.values()) {
+			items.push({ label: `+${point.count} ${point.construct}`, description: `line ${point.line + 1}`, detail: sourceLine(point.line), line: point.line });
+		}
+
+		items.push({ label: `Cognitive complexity ${func.cognitiveComplexity} (not part of the score)`, kind: vscode.QuickPickItemKind.Separator });
+		const increments = func.cognitiveIncrements || [];
+		if (increments.length === 0) {
+			items.push({ label: 'No branches, loops or boolean sequences' });
+		}
+		for (const step of increments) {
+			items.push({
+				label: `+${step.increment} ${step.construct}`,
+				description: `line ${step.line + 1}`,
+				detail: step.nesting > 0
+					? `1 for the ${step.construct}, +${step.nesting} for nesting`
+					: sourceLine(step.line),
+				line: step.line
+			});
+		}
+
+		const selected = await vscode.window.showQuickPick(items, {
+			placeHolder: `${func.name}: ${level} (score ${scored.score}) · CCN ${func.cyclomaticComplexity} · ${func.nloc} NLOC`,
+			matchOnDescription: true
+		});
+
+		if (selected?.line !== undefined) {
+			const position = new vscode.Position(selected.line, 0);
+			activeEditor.selection = new vscode.Selection(position, position);
+			activeEditor.revealRange(new vscode.Range(position, position), vscode.TextEditorRevealType.InCenter);
+		}
+	});
+
+	// Build the workspace call graph with transitive complexity
+	const showCallGraphCommand = vscode.commands.registerCommand('voight.showCallGraph', async () => {
+		const format = await vscode.window.showQuickPick([
+			{ label: 'Ranking', description: 'Functions by transitive complexity', format: 'ranking' },
+			{ label: 'DOT', description: 'Graphviz source', format: 'dot' },
+			{ label: 'JSON', description: 'Nodes and edges', format: 'json' }
+		], { placeHolder: 'Call graph output' });
+
+		if (!format) {
+			return;
+		}
+
+		const builder = new CallGraphBuilder();
+		const graph = await vscode.window.withProgress({
+			location: vscode.ProgressLocation.Notification,
+			title: 'Voight: Building call graph...'
+		}, async () => {
+			// Each go.mod maps its import paths to directories, so pkg.Func( links to the right package
+			const goMods = await vscode.workspace.findFiles('**/go.mod', EXCLUDED_GLOB);
+			for (const goMod of goMods) {
+				const content = Buffer.from(await vscode.workspace.fs.readFile(goMod)).toString('utf8');
+				const modulePath = /^module\s+(\S+)/m.exec(content)?.[1];
+				if (modulePath) {
+					builder.addGoModule(path.dirname(vscode.workspace.asRelativePath(goMod, false)), modulePath);
+				}
+			}
+
+			for (const { uri, content } of await readWorkspaceSources(workspaceFileFilter, 'Call graph')) {
+				builder.addFile(vscode.workspace.asRelativePath(uri, false), content, uri.toString());
+			}
+
+			return builder.build();
+		});
+
+		if (graph.nodes.length === 0) {
+			vscode.window.showInformationMessage('No functions found in the workspace');
+			return;
+		}
+
+		if (format.format !== 'ranking') {
+			const doc = await vscode.workspace.openTextDocument({
+				content: format.format === 'dot' ? CallGraphBuilder.toDot(graph) : CallGraphBuilder.toJson(graph),
+				language: format.format === 'dot' ? 'dot' : 'json'
+			});
+			await vscode.window.showTextDocument(doc);
+			return;
+		}
+
+		// Display in quick pick
+		const items = graph.nodes.slice(0, 50).map(node => ({
+			label: `${node.functionName} - transitive ${node.transitiveComplexity}`,
+			description: `${node.filePath}:${node.startLine + 1} · own ${node.cognitiveComplexity} · ${node.callees.length} callee${node.callees.length === 1 ? '' : 's'}`,
+			node
+		}));
+
+		const selected = await vscode.window.showQuickPick(items, {
+			placeHolder: `${graph.nodes.length} functions, ${graph.edges.length} resolved calls`
+		});
+
+		// filePath is for display: in multi-root workspaces it starts with the folder name
+		if (selected?.node.uri) {
+			const doc = await vscode.workspace.openTextDocument(vscode.Uri.parse(selected.node.uri));
+			const editor = await vscode.window.showTextDocument(doc);
+			const position = new vscode.Position(selected.node.startLine, 0);
+			editor.selection = new vscode.Selection(position, position);
+			editor.revealRange(new vscode.Range(position, position), vscode.TextEditorRevealType.InCenter);
+		}
+	});
+
+	// Rank Go packages by coupling and abstractness from their imports
+	const showPackageCouplingCommand = vscode.commands.registerCommand('voight.showPackageCoupling', async () => {
+		const format = await vscode.window.showQuickPick([
+			{ label: 'Ranking', description: 'Packages by distance from the main sequence', format: 'ranking' },
+			{ label: 'DOT', description: 'Graphviz source', format: 'dot' },
+			{ label: 'JSON', description: 'Packages and dependencies', format: 'json' }
+		], { placeHolder: 'Package coupling output' });
+
+		if (!format) {
+			return;
+		}
+
+		const { graph, moduleRoot } = await vscode.window.withProgress({
+			location: vscode.ProgressLocation.Notification,
+			title: 'Voight: Analyzing package coupling...'
+		}, async () => {
+			// The outermost go.mod gives the module root and the import path prefix
+			const goMod = (await vscode.workspace.findFiles('**/go.mod', EXCLUDED_GLOB))
+				.sort((a, b) => a.fsPath.length - b.fsPath.length)[0];
+			const moduleRoot = goMod ? path.dirname(goMod.fsPath) : workspaceRoot;
+			let modulePath: string | undefined;
+			if (goMod) {
+				const content = Buffer.from(await vscode.workspace.fs.readFile(goMod)).toString('utf8');
+				modulePath = /^module\s+(\S+)/m.exec(content)?.[1];
+			}
+
+			const builder = new PackageGraphBuilder(modulePath);
+			for (const { uri, content } of await readWorkspaceSources(workspaceFileFilter, 'Package coupling', '**/*.go')) {
+				const relativePath = path.relative(moduleRoot, uri.fsPath);
+				if (!relativePath.startsWith('..')) {
+					builder.addFile(relativePath, content);
+				}
+			}
+
+			return { graph: builder.build(), moduleRoot };
+		});
+
+		if (graph.packages.length === 0) {
+			vscode.window.showInformationMessage('No Go packages found in the workspace');
+			return;
+		}
+
+		if (format.format !== 'ranking') {
+			const doc = await vscode.workspace.openTextDocument({
+				content: format.format === 'dot' ? PackageGraphBuilder.toDot(graph) : PackageGraphBuilder.toJson(graph),
+				language: format.format === 'dot' ? 'dot' : 'json'
+			});
+			await vscode.window.showTextDocument(doc);
+			return;
+		}
+
+		// Display in quick pick
+		// Function metrics sit next to the package metrics; picking a package opens its most complex function
+		const items = graph.packages.map(pkg => {
+			const hottest = pkg.mostComplexFunction;
+			return {
+				label: `${pkg.name} - ${pkg.distance !== undefined ? `D ${pkg.distance.toFixed(2)}` : 'no coupling in the module'}`,
+				description: pkg.directory,
+				detail: `Ca ${pkg.afferentCoupling} · Ce ${pkg.efferentCoupling} · ` +
+					(pkg.instability !== undefined ? `instability ${pkg.instability.toFixed(2)} · ` : '') +
+					`abstractness ${pkg.abstractness.toFixed(2)} · ${pkg.functionCount} functions, cognitive ${pkg.cognitiveComplexity}` +
+					(pkg.maintainabilityIndex !== undefined ? ` · MI ${pkg.maintainabilityIndex}` : '') +
+					(hottest ? ` · most complex: ${hottest.name} (${hottest.cognitiveComplexity})` : ''),
+				pkg
+			};
+		});
+
+		const selected = await vscode.window.showQuickPick(items, {
+			placeHolder: `${graph.packages.length} packages, ${graph.dependencies.length} imports within the module`,
+			matchOnDescription: true
+		});
+
+		if (selected) {
+			const hottest = selected.pkg.mostComplexFunction;
+			const uri = vscode.Uri.file(path.join(moduleRoot, hottest ? hottest.filePath : selected.pkg.files[0]));
+			const editor = await vscode.window.showTextDocument(await vscode.workspace.openTextDocument(uri));
+			if (hottest) {
+				const position = new vscode.Position(hottest.startLine, 0);
+				editor.selection = new vscode.Selection(position, position);
+				editor.revealRange(new vscode.Range(position, position), vscode.TextEditorRevealType.InCenter);
+			}
+		}
+	});
+
+	// Compare "Complexity: <level>" doc comments with computed levels, and rewrite stale ones
+	const verifyAnnotationsCommand = vscode.commands.registerCommand('voight.verifyAnnotations', async () => {
+		const checks = await vscode.window.withProgress({
+			location: vscode.ProgressLocation.Notification,
+			title: 'Voight: Verifying complexity annotations...'
+		}, async () => {
+			// Open files are read from their buffer, so positions match what an edit will be applied to
+			const found: Array<{ uri: vscode.Uri; check: AnnotationCheck }> = [];
+			for (const { uri, content } of await readWorkspaceSources(workspaceFileFilter, 'Annotation check')) {
+				if (!/Complexity:/i.test(content)) {
+					continue;
+				}
+				try {
+					for (const check of AnnotationVerifier.verify(content, uri.fsPath, complexityThresholds.forFile(uri.fsPath))) {
+						found.push({ uri, check });
+					}
+				} catch (error) {
+					Logger.warn(`Annotation check skipped ${uri.fsPath}: ${error}`);
+				}
+			}
+			return found;
+		});
+
+		const stale = checks.filter(entry => !entry.check.matches);
+		if (checks.length === 0) {
+			vscode.window.showInformationMessage('No "Complexity:" annotations found in the workspace');
+			return;
+		}
+		if (stale.length === 0) {
+			vscode.window.showInformationMessage(`All ${checks.length} complexity annotations match`);
+			return;
+		}
+
+		// Display stale annotations, with a fix-all entry first
+		const fixAll = { label: `$(wrench) Fix all ${stale.length} stale annotations`, description: '', entry: undefined };
+		const items = [fixAll, ...stale.map(entry => ({
+			label: `${entry.check.functionName}: ${entry.check.annotated} → ${entry.check.computed}`,
+			description: `${vscode.workspace.asRelativePath(entry.uri, false)}:${entry.check.line + 1} · score ${entry.check.score}/10`,
+			entry
+		}))];
+
+		const selected = await vscode.window.showQuickPick(items, {
+			placeHolder: `${stale.length} of ${checks.length} complexity annotations disagree with the computed level`,
+			matchOnDescription: true
+		});
+
+		if (!selected) {
+			return;
+		}
+
+		if (!selected.entry) {
+			// Re-verify each file against its live document, which may have changed since the scan
+			const edit = new vscode.WorkspaceEdit();
+			let fixedCount = 0;
+			for (const uri of new Set(stale.map(entry => entry.uri.toString()))) {
+				const document = await vscode.workspace.openTextDocument(vscode.Uri.parse(uri));
+				const content = document.getText();
+				const fileChecks = AnnotationVerifier.verify(content, document.fileName, complexityThresholds.forFile(document.fileName));
+				const staleChecks = fileChecks.filter(check => !check.matches);
+				if (staleChecks.length === 0) {
+					continue;
+				}
+				const fullRange = new vscode.Range(document.positionAt(0), document.positionAt(content.length));
+				edit.replace(document.uri, fullRange, AnnotationVerifier.fix(content, staleChecks));
+				fixedCount += staleChecks.length;
+			}
+			await vscode.workspace.applyEdit(edit);
+			vscode.window.showInformationMessage(`Updated ${fixedCount} complexity annotations`);
+			return;
+		}
+
+		const editor = await vscode.window.showTextDocument(await vscode.workspace.openTextDocument(selected.entry.uri));
+		const position = new vscode.Position(selected.entry.check.line, selected.entry.check.startColumn);
+		editor.selection = new vscode.Selection(position, position);
+		editor.revealRange(new vscode.Range(position, position), vscode.TextEditorRevealType.InCenter);
+	});
+
+	// Diff function complexity between two git refs as a Markdown summary
+	const compareRefsCommand = vscode.commands.registerCommand('voight.compareRefs', async () => {
+		const baseRef = await vscode.window.showInputBox({
+			prompt: 'Base ref (older revision)',
+			value: 'HEAD~1',
+			placeHolder: 'e.g. v1.4.0, main, HEAD~10'
+		});
+		if (!baseRef) {
+			return;
+		}
+		const targetRef = await vscode.window.showInputBox({
+			prompt: `Compare ${baseRef} with`,
+			value: 'HEAD'
+		});
+		if (!targetRef) {
+			return;
+		}
+
+		// Input goes to git argv, so only refs that name a commit get past here
+		for (const ref of [baseRef, targetRef]) {
+			try {
+				await resolveRef(workspaceRoot, ref);
+			} catch (error) {
+				Logger.warn(`Ref comparison could not resolve ${ref}: ${error}`);
+				vscode.window.showWarningMessage(`"${ref}" is not a commit in this repository`);
+				return;
+			}
+		}
+
+		let comparison: RevisionComparison;
+		try {
+			comparison = await vscode.window.withProgress({
+				location: vscode.ProgressLocation.Notification,
+				title: `Voight: Comparing ${baseRef} and ${targetRef}...`
+			}, () => compareRevisions(workspaceRoot, baseRef, targetRef, {
+				// Honour voight.detection.includeGenerated / includeVendored
+				include: (file, content) => !workspaceFileFilter.isExcludedGenerated(path.join(workspaceRoot, file), content)
+			}));
+		} catch (error) {
+			Logger.warn(`Ref comparison of ${baseRef}..${targetRef} failed: ${error}`);
+			vscode.window.showWarningMessage(`Could not compare ${baseRef} and ${targetRef} - see the Voight output for the git error`);
+			return;
+		}
+
+		const doc = await vscode.workspace.openTextDocument({
+			content: RevisionComparer.toMarkdown(comparison),
+			language: 'markdown'
+		});
+		await vscode.window.showTextDocument(doc);
+	});
+
 	// Clear file tracking data
 	const clearFileTrackingCommand = vscode.commands.registerCommand('voight.clearFileTracking', async () => {
 		const result = await vscode.window.showWarningMessage(
@@ -492,6 +1109,16 @@ Last run: ${gcStats.lastRunAt > 0 ? new Date(gcStats.lastRunAt).toLocaleString()
 		showFileTrackingStatsCommand,
 		exportFileRankingsCommand,
 		getCurrentFileTrackingCommand,
+		assessAiLikelihoodCommand,
+		findClonesCommand,
+		showHotspotsCommand,
+		checkStructureCommand,
+		estimateGrowthCommand,
+		explainComplexityCommand,
+		showCallGraphCommand,
+		showPackageCouplingCommand,
+		verifyAnnotationsCommand,
+		compareRefsCommand,
 		clearFileTrackingCommand,
 		showGCStatsCommand,
 		runGCCommand,
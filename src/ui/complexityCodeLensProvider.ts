@@ -0,0 +1,85 @@
+import * as vscode from 'vscode';
+import { Logger } from '../utils/logger';
+import { ComplexityAnalyzer } from '../detection/complexity/analyzer';
+
+/**
+ * Languages the complexity analyzer supports
+ */
+export const COMPLEXITY_LANGUAGES: vscode.DocumentSelector = [
+    { scheme: 'file', language: 'typescript' },
+    { scheme: 'file', language: 'typescriptreact' },
+    { scheme: 'file', language: 'javascript' },
+    { scheme: 'file', language: 'javascriptreact' },
+    { scheme: 'file', language: 'go' },
+    { scheme: 'file', language: 'python' }
+];
+
+/**
+ * Shows complexity metrics above each function
+ * Lenses are cached per document version, so re-rendering an unchanged
+ * document (scrolling, switching tabs) does not re-analyze it
+ */
+export class ComplexityCodeLensProvider implements vscode.CodeLensProvider {
+    private _onDidChangeCodeLenses = new vscode.EventEmitter<void>();
+    public readonly onDidChangeCodeLenses: vscode.Event<void> = this._onDidChangeCodeLenses.event;
+
+    private _cache: Map<string, { version: number; lenses: vscode.CodeLens[] }> = new Map();
+    private _listeners: vscode.Disposable[] = [];
+
+    constructor() {
+        this._listeners.push(
+            // Watch for configuration changes
+            vscode.workspace.onDidChangeConfiguration((e) => {
+                if (e.affectsConfiguration('voight.codeLens.enabled')) {
+                    Logger.debug('[ComplexityCodeLens] Setting changed, refreshing lenses');
+                    this._onDidChangeCodeLenses.fire();
+                }
+            }),
+            vscode.workspace.onDidCloseTextDocument((document) => {
+                this._cache.delete(document.uri.toString());
+            })
+        );
+    }
+
+    public provideCodeLenses(document: vscode.TextDocument): vscode.CodeLens[] {
+        const enabled = vscode.workspace.getConfiguration('voight').get<boolean>('codeLens.enabled', false);
+        if (!enabled) {
+            return [];
+        }
+
+        const key = document.uri.toString();
+        const cached = this._cache.get(key);
+        if (cached && cached.version === document.version) {
+            return cached.lenses;
+        }
+
+        let lenses: vscode.CodeLens[] = [];
+        try {
+            const analysis = ComplexityAnalyzer.forFile(document.fileName).analyze(document.getText());
+
+            lenses = analysis.functions
+                .filter(func => func.name !== '*global*' && func.startLine < document.lineCount)
+                .map(func => new vscode.CodeLens(
+                    new vscode.Range(func.startLine, 0, func.startLine, 0),
+                    {
+                        title: `Cognitive complexity: ${func.cognitiveComplexity} · CCN: ${func.cyclomaticComplexity}` +
+                            (func.maintainabilityIndex !== undefined ? ` · MI: ${func.maintainabilityIndex}` : ''),
+                        command: 'voight.explainComplexity',
+                        tooltip: 'Show the decision points and size behind the complexity score',
+                        arguments: [document.uri, func.startLine]
+                    }
+                ));
+        } catch (error) {
+            Logger.warn(`[ComplexityCodeLens] Analysis failed for ${document.fileName}: ${error}`);
+        }
+
+        this._cache.set(key, { version: document.version, lenses });
+        return lenses;
+    }
+
+    public dispose(): void {
+        this._listeners.forEach(listener => listener.dispose());
+        this._onDidChangeCodeLenses.dispose();
+        this._cache.clear();
+    }
+}
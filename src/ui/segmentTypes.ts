@@ -156,7 +156,7 @@ export class SegmentFactory {
                 complexity: segment.metadata.complexityScore,
                 // Store additional complexity data in analysis for reference
                 ...(segment.metadata.complexityData && {
-                    codeQuality: `CCN: ${segment.metadata.complexityData.ccn}, NLOC: ${segment.metadata.complexityData.nloc}`,
+                    codeQuality: this.formatCodeQuality(segment.metadata.complexityData),
                     suggestions: [`Complexity Level: ${segment.metadata.complexityData.level}`]
                 })
             } : undefined,
@@ -166,6 +166,22 @@ export class SegmentFactory {
         };
     }
 
+    /**
+     * Summarize complexity metrics for display
+     * Segments persisted before cognitive complexity and MI were tracked lack those fields
+     */
+    private static formatCodeQuality(data: Record<string, any>): string {
+        const parts = [`CCN: ${data.ccn}`];
+        if (data.cognitive !== undefined) {
+            parts.push(`Cognitive: ${data.cognitive}`);
+        }
+        parts.push(`NLOC: ${data.nloc}`);
+        if (data.maintainabilityIndex !== undefined) {
+            parts.push(`MI: ${data.maintainabilityIndex}`);
+        }
+        return parts.join(', ');
+    }
+
     private static extractFileName(filePath: string): string {
         const parts = filePath.split(/[\\/]/);
         return parts[parts.length - 1];
@@ -0,0 +1,135 @@
+import * as vscode from 'vscode';
+import * as path from 'path';
+import { Logger } from '../utils/logger';
+import { ComplexityThresholds } from '../utils/complexityThresholds';
+import { StructureDetector, StructureFinding } from '../detection/structure';
+import { buildPluginRequest, runPlugin, PluginConfig, PluginSeverity } from '../detection/plugins';
+
+const SEVERITIES: Record<PluginSeverity, vscode.DiagnosticSeverity> = {
+    error: vscode.DiagnosticSeverity.Error,
+    warning: vscode.DiagnosticSeverity.Warning,
+    info: vscode.DiagnosticSeverity.Information
+};
+
+/**
+ * Publishes structure rule and plugin findings as editor diagnostics
+ * Files are checked on demand and re-checked on save until closed
+ * Severities come from voight.ruleSeverities, else the rule's own default
+ * (warning unless it says otherwise) and whatever the plugin reported for plugin findings
+ */
+export class StructureDiagnostics implements vscode.Disposable {
+    private _collection = vscode.languages.createDiagnosticCollection('voight');
+    private _thresholds: ComplexityThresholds;
+    private _checkedFiles: Set<string> = new Set();
+    private _pluginRuns: Map<string, number> = new Map();
+    private _listeners: vscode.Disposable[] = [];
+
+    constructor(thresholds: ComplexityThresholds) {
+        this._thresholds = thresholds;
+        this._listeners.push(
+            vscode.workspace.onDidSaveTextDocument((document) => {
+                if (this._checkedFiles.has(document.uri.toString())) {
+                    this.check(document);
+                }
+            }),
+            vscode.workspace.onDidCloseTextDocument((document) => {
+                this._checkedFiles.delete(document.uri.toString());
+                this._pluginRuns.delete(document.uri.toString());
+                this._collection.delete(document.uri);
+            })
+        );
+    }
+
+    /**
+     * Check a document and publish its findings
+     */
+    public check(document: vscode.TextDocument): StructureFinding[] {
+        const countStandardLibrary = vscode.workspace.getConfiguration('voight').get<boolean>('structure.countStandardLibrary', true);
+        const detector = new StructureDetector(this._thresholds.rulesForFile(document.fileName), { countStandardLibrary });
+        const findings = detector.detect(document.getText(), document.fileName);
+
+        const diagnostics = findings.map(finding => {
+            const line = Math.min(finding.startLine, document.lineCount - 1);
+            const diagnostic = new vscode.Diagnostic(
+                document.lineAt(line).range,
+                finding.message,
+                this.severityFor(finding.ruleId, finding.severity ?? 'warning')
+            );
+            diagnostic.source = 'voight';
+            diagnostic.code = finding.ruleId;
+            return diagnostic;
+        });
+
+        this._checkedFiles.add(document.uri.toString());
+        this._collection.set(document.uri, diagnostics);
+        Logger.debug(`[StructureDiagnostics] ${findings.length} findings in ${document.fileName}`);
+
+        void this.runPlugins(document, diagnostics);
+
+        return findings;
+    }
+
+    /**
+     * Run voight.plugins on a document and publish their findings next to the structure findings
+     * Skipped in untrusted workspaces, since plugins are arbitrary commands
+     */
+    private async runPlugins(document: vscode.TextDocument, structureDiagnostics: vscode.Diagnostic[]): Promise<void> {
+        const plugins = vscode.workspace.getConfiguration('voight').get<PluginConfig[]>('plugins', [])
+            .filter(plugin => plugin && typeof plugin.name === 'string' && typeof plugin.command === 'string');
+        if (plugins.length === 0 || !vscode.workspace.isTrusted) {
+            return;
+        }
+
+        const key = document.uri.toString();
+        const run = (this._pluginRuns.get(key) || 0) + 1;
+        this._pluginRuns.set(key, run);
+
+        const request = buildPluginRequest(document.getText(), document.fileName);
+        const cwd = vscode.workspace.getWorkspaceFolder(document.uri)?.uri.fsPath ?? path.dirname(document.fileName);
+        const results = await Promise.all(plugins.map(async plugin => {
+            try {
+                return await runPlugin(plugin, request, cwd);
+            } catch (error) {
+                Logger.warn(`[StructureDiagnostics] Plugin ${plugin.name} failed: ${error}`);
+                return [];
+            }
+        }));
+
+        // The file was closed or checked again while the plugins ran
+        if (this._pluginRuns.get(key) !== run || !this._checkedFiles.has(key)) {
+            return;
+        }
+
+        const lastLine = document.lineCount - 1;
+        const pluginDiagnostics = results.flat().map(finding => {
+            const start = Math.max(0, Math.min(finding.startLine, lastLine));
+            const end = Math.max(start, Math.min(finding.endLine, lastLine));
+            const diagnostic = new vscode.Diagnostic(
+                new vscode.Range(start, 0, end, document.lineAt(end).text.length),
+                finding.message,
+                this.severityFor(finding.ruleId, finding.severity)
+            );
+            diagnostic.source = finding.ruleId.split('/')[0];
+            diagnostic.code = finding.ruleId;
+            return diagnostic;
+        });
+
+        this._collection.set(document.uri, [...structureDiagnostics, ...pluginDiagnostics]);
+        Logger.debug(`[StructureDiagnostics] ${pluginDiagnostics.length} plugin findings in ${document.fileName}`);
+    }
+
+    /**
+     * Configured severity for a rule ID, or the fallback when unset or invalid
+     */
+    private severityFor(ruleId: string, fallback: PluginSeverity): vscode.DiagnosticSeverity {
+        const configured = vscode.workspace.getConfiguration('voight').get<Record<string, PluginSeverity>>('ruleSeverities', {})[ruleId];
+        return SEVERITIES[Object.keys(SEVERITIES).includes(configured) ? configured : fallback];
+    }
+
+    public dispose(): void {
+        this._listeners.forEach(listener => listener.dispose());
+        this._collection.dispose();
+        this._checkedFiles.clear();
+        this._pluginRuns.clear();
+    }
+}
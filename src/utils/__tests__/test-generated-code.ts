@@ -0,0 +1,44 @@
+/**
+ * Test for generated and vendored code detection
+ *
+ * Run with: npx ts-node src/utils/__tests__/test-generated-code.ts
+ */
+
+import * as fs from 'fs';
+import * as path from 'path';
+import { classifyGenerated, hasGeneratedHeader } from '../generatedCode';
+import { createChecks } from '../../__tests__/checks';
+
+function testGeneratedCode(): boolean {
+    console.log('\n=== Testing generated code detection ===\n');
+    const { check, finish } = createChecks();
+
+    // Test 1: headers
+    console.log('Test 1: Headers');
+    check('Go canonical header', hasGeneratedHeader('// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n'), true);
+    check('header after a build tag', hasGeneratedHeader('//go:build linux\n\n// Code generated by stringer -type=Kind; DO NOT EDIT.\n'), true);
+    check('Python protoc header', hasGeneratedHeader('# -*- coding: utf-8 -*-\n# Generated by the protocol buffer compiler.  DO NOT EDIT!\n'), true);
+    check('@generated marker', hasGeneratedHeader('/**\n * @generated\n */\nexport const schema = {};\n'), true);
+    check('mention in code is not a header', hasGeneratedHeader('const msg = "Code generated by x. DO NOT EDIT.";\n'), false);
+    check('hand-written file', hasGeneratedHeader('package main\n\n// Handler serves requests\nfunc Handler() {}\n'), false);
+    check('@generated mentioned mid-sentence', hasGeneratedHeader('/**\n * Skips files carrying the @generated marker.\n */\nexport function skip() {}\n'), false);
+    check('marker below the first line of code', hasGeneratedHeader('package api\n\nfunc A() {}\n\n// Code generated by x. DO NOT EDIT.\n'), false);
+    check('@generated below the first line of code', hasGeneratedHeader('export const a = 1;\n/** @generated */\n'), false);
+    check('header below a license block', hasGeneratedHeader('/*\n * Copyright 2024 Acme\n */\n\n// @generated by gen-types\n'), true);
+    check('this detector is not generated', hasGeneratedHeader(fs.readFileSync(path.join(__dirname, '../generatedCode.ts'), 'utf8')), false);
+
+    // Test 2: paths
+    console.log('\nTest 2: Paths');
+    check('vendor directory', classifyGenerated('vendor/github.com/pkg/errors/errors.go'), 'vendored');
+    check('nested third_party', classifyGenerated('libs/third_party/zlib/inflate.py'), 'vendored');
+    check('protobuf suffix', classifyGenerated('api/v1/user.pb.go'), 'generated');
+    check('mockgen file', classifyGenerated('internal/store/mock_store.go'), 'generated');
+    check('file named vendor.go', classifyGenerated('pkg/vendor.go'), undefined);
+    check('header without a generated name', classifyGenerated('api/enum.go', '// Code generated by enumer; DO NOT EDIT.\n'), 'generated');
+    check('project code', classifyGenerated('internal/api/handler.go', 'package api\n'), undefined);
+
+    return finish();
+}
+
+// Run the test
+process.exit(testGeneratedCode() ? 0 : 1);
@@ -0,0 +1,102 @@
+import * as vscode from 'vscode';
+import { Logger } from './logger';
+import { FilePatternMatcher } from './filePatternMatcher';
+import { ComplexityScorer } from '../detection/complexity/scorer';
+import { ComplexityLevelThresholds } from '../detection/complexity/types';
+import { StructureRuleThresholds } from '../detection/structure/types';
+
+/**
+ * Per-path threshold override from voight.complexity.overrides
+ */
+interface ThresholdOverride {
+    pattern: string;
+    thresholds?: Partial<ComplexityLevelThresholds>;
+    rules?: Partial<StructureRuleThresholds>;
+}
+
+/**
+ * Resolves complexity level thresholds and structure rule limits for a file
+ * Starts from voight.complexity.thresholds and voight.rules, then applies
+ * every matching entry in voight.complexity.overrides in order, so later entries win
+ */
+export class ComplexityThresholds implements vscode.Disposable {
+    private defaults: ComplexityLevelThresholds = ComplexityScorer.getConfig().levels;
+    private rules: Partial<StructureRuleThresholds> = {};
+    private overrides: ThresholdOverride[] = [];
+    private _configListener: vscode.Disposable;
+
+    constructor() {
+        this.loadThresholds();
+
+        // Watch for configuration changes
+        this._configListener = vscode.workspace.onDidChangeConfiguration((e) => {
+            if (e.affectsConfiguration('voight.complexity') || e.affectsConfiguration('voight.rules')) {
+                Logger.debug('[ComplexityThresholds] Thresholds changed, reloading...');
+                this.loadThresholds();
+            }
+        });
+    }
+
+    public dispose(): void {
+        this._configListener.dispose();
+    }
+
+    /**
+     * Load thresholds and overrides from configuration
+     */
+    private loadThresholds(): void {
+        const config = vscode.workspace.getConfiguration('voight.complexity');
+        const builtIn = ComplexityScorer.getConfig().levels;
+
+        this.defaults = {
+            ...builtIn,
+            ...config.get<Partial<ComplexityLevelThresholds>>('thresholds', {})
+        };
+
+        // Unset rules fall back to the detector's defaults
+        this.rules = vscode.workspace.getConfiguration('voight').get<Partial<StructureRuleThresholds>>('rules', {});
+
+        this.overrides = [];
+        for (const entry of config.get<ThresholdOverride[]>('overrides', [])) {
+            if (!entry || typeof entry.pattern !== 'string' || (!entry.thresholds && !entry.rules)) {
+                Logger.warn(`[ComplexityThresholds] Ignoring invalid override: ${JSON.stringify(entry)}`);
+                continue;
+            }
+            this.overrides.push(entry);
+        }
+
+        Logger.debug(`[ComplexityThresholds] Loaded thresholds ${JSON.stringify(this.defaults)} with ${this.overrides.length} overrides`);
+    }
+
+    /**
+     * Get the thresholds that apply to a file
+     */
+    public forFile(filePath: string): ComplexityLevelThresholds {
+        const relativePath = FilePatternMatcher.toRelativePath(filePath);
+        let thresholds = this.defaults;
+
+        for (const override of this.overrides) {
+            if (override.thresholds && FilePatternMatcher.matchGlob(relativePath, override.pattern)) {
+                thresholds = { ...thresholds, ...override.thresholds };
+            }
+        }
+
+        return thresholds;
+    }
+
+    /**
+     * Get the structure rule limits that apply to a file
+     */
+    public rulesForFile(filePath: string): Partial<StructureRuleThresholds> {
+        const relativePath = FilePatternMatcher.toRelativePath(filePath);
+        let rules = this.rules;
+
+        for (const override of this.overrides) {
+            if (override.rules && FilePatternMatcher.matchGlob(relativePath, override.pattern)) {
+                rules = { ...rules, ...override.rules };
+            }
+        }
+
+        return rules;
+    }
+}
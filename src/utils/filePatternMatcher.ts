@@ -1,20 +1,26 @@
 import * as vscode from 'vscode';
 import { Logger } from './logger';
+import { classifyGenerated } from './generatedCode';
 
 /**
  * Utility for matching files against exclusion patterns
- * Supports both glob patterns and regex
+ * Supports both glob patterns and regex, and skips generated and vendored
+ * code unless voight.detection.includeGenerated / includeVendored are set
  */
 export class FilePatternMatcher {
     private globPatterns: string[] = [];
     private regexPatterns: RegExp[] = [];
+    private includeGenerated = false;
+    private includeVendored = false;
 
     constructor() {
         this.loadPatterns();
 
         // Watch for configuration changes
         vscode.workspace.onDidChangeConfiguration((e) => {
-            if (e.affectsConfiguration('voight.detection.excludePatterns')) {
+            if (e.affectsConfiguration('voight.detection.excludePatterns') ||
+                e.affectsConfiguration('voight.detection.includeGenerated') ||
+                e.affectsConfiguration('voight.detection.includeVendored')) {
                 Logger.debug('[FilePatternMatcher] Exclude patterns changed, reloading...');
                 this.loadPatterns();
             }
@@ -27,6 +33,8 @@ export class FilePatternMatcher {
     private loadPatterns(): void {
         const config = vscode.workspace.getConfiguration('voight.detection');
         const patterns = config.get<string[]>('excludePatterns', []);
+        this.includeGenerated = config.get<boolean>('includeGenerated', false);
+        this.includeVendored = config.get<boolean>('includeVendored', false);
 
         this.globPatterns = [];
         this.regexPatterns = [];
@@ -55,18 +63,11 @@ export class FilePatternMatcher {
 
     /**
      * Check if a file should be excluded from detection
+     * Pass the start of the file to also check for a generated-code header
      */
-    public shouldExclude(filePath: string): boolean {
+    public shouldExclude(filePath: string, sourceCode?: string): boolean {
         // Convert to workspace-relative path for glob matching
-        const workspaceFolder = vscode.workspace.workspaceFolders?.[0];
-        let relativePath = filePath;
-
-        if (workspaceFolder) {
-            const workspacePath = workspaceFolder.uri.fsPath;
-            if (filePath.startsWith(workspacePath)) {
-                relativePath = filePath.substring(workspacePath.length + 1);
-            }
-        }
+        const relativePath = FilePatternMatcher.toRelativePath(filePath);
 
         // Exclude dotfiles and dot directories (files/folders starting with .)
         const pathParts = relativePath.split(/[/\\]/);
@@ -76,10 +77,14 @@ export class FilePatternMatcher {
             return true;
         }
 
+        if (this.isExcludedGenerated(filePath, sourceCode)) {
+            return true;
+        }
+
         // Check glob patterns
         for (const pattern of this.globPatterns) {
             // Use custom glob matching
-            if (this.matchGlob(relativePath, pattern)) {
+            if (FilePatternMatcher.matchGlob(relativePath, pattern)) {
                 Logger.debug(`[FilePatternMatcher] File excluded by glob pattern "${pattern}": ${filePath}`);
                 return true;
             }
@@ -96,11 +101,42 @@ export class FilePatternMatcher {
         return false;
     }
 
+    /**
+     * Check if a file is generated or vendored code that is excluded by default
+     */
+    public isExcludedGenerated(filePath: string, sourceCode?: string): boolean {
+        const kind = classifyGenerated(FilePatternMatcher.toRelativePath(filePath), sourceCode);
+
+        if ((kind === 'generated' && !this.includeGenerated) || (kind === 'vendored' && !this.includeVendored)) {
+            Logger.debug(`[FilePatternMatcher] File excluded (${kind} code): ${filePath}`);
+            return true;
+        }
+
+        return false;
+    }
+
+    /**
+     * Convert an absolute path to a path relative to the first workspace folder
+     * Paths outside the workspace are returned unchanged
+     */
+    public static toRelativePath(filePath: string): string {
+        const workspaceFolder = vscode.workspace.workspaceFolders?.[0];
+
+        if (workspaceFolder) {
+            const workspacePath = workspaceFolder.uri.fsPath;
+            if (filePath.startsWith(workspacePath)) {
+                return filePath.substring(workspacePath.length + 1);
+            }
+        }
+
+        return filePath;
+    }
+
     /**
      * Simple glob matcher for common patterns
      * Supports: *, **, ?, and basic path matching
      */
-    private matchGlob(path: string, pattern: string): boolean {
+    public static matchGlob(path: string, pattern: string): boolean {
         // Normalize path separators
         path = path.replace(/\\/g, '/');
         pattern = pattern.replace(/\\/g, '/');
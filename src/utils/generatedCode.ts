@@ -0,0 +1,106 @@
+/**
+ * Generated and vendored code detection
+ *
+ * Generated files are recognized by the canonical header
+ * ("// Code generated <tool>; DO NOT EDIT.", see https://go.dev/s/generatedcode)
+ * or an @generated marker in the comments before the first line of code, or
+ * by a well-known generator file suffix. Vendored code is anything under a
+ * vendor/ or third_party/ directory.
+ */
+
+export type GeneratedKind = 'generated' | 'vendored';
+
+const VENDOR_DIRECTORIES = new Set(['vendor', 'third_party', 'third-party', 'thirdparty']);
+
+/**
+ * File name endings written by protoc, grpc, mockgen and similar tools
+ */
+const GENERATED_SUFFIXES = [
+    '.pb.go', '.pb.gw.go', '_pb2.py', '_pb2_grpc.py', '_pb2.pyi', '_pb.js', '_pb.d.ts', '_grpc_pb.js',
+    '_mock.go', '.mock.go', '_gen.go', '.gen.go', '.generated.ts', '.generated.js'
+];
+
+/**
+ * Header comments that mark a file as generated, in any comment syntax
+ */
+const GENERATED_HEADERS = [
+    /^\s*(?:\/\/|#|\/?\*+)\s*Code generated .* DO NOT EDIT\.?\s*$/m,
+    /^\s*(?:\/\/|#|\/?\*+)\s*Generated by .* DO NOT EDIT/m,
+    /^\s*(?:\/\/|#|\/?\*+)\s*@generated\b/m
+];
+
+const COMMENT_START = /^(?:\/\/|#|\/\*|\*)/;
+
+/**
+ * Headers are only looked for near the top of the file
+ */
+const HEADER_SCAN_LENGTH = 4096;
+
+/**
+ * Whether a path is inside a vendored dependency directory
+ */
+export function isVendoredPath(relativePath: string): boolean {
+    const parts = relativePath.replace(/\\/g, '/').split('/');
+    return parts.slice(0, -1).some(part => VENDOR_DIRECTORIES.has(part));
+}
+
+/**
+ * Whether a file name follows a code generator's naming convention
+ */
+export function hasGeneratedName(relativePath: string): boolean {
+    const fileName = relativePath.replace(/\\/g, '/').split('/').pop() || '';
+    return GENERATED_SUFFIXES.some(suffix => fileName.endsWith(suffix)) ||
+        (fileName.startsWith('mock_') && fileName.endsWith('.go'));
+}
+
+/**
+ * Whether a file starts with a generated-code header
+ *
+ * @param sourceCode - File contents (only the leading comments are checked)
+ */
+export function hasGeneratedHeader(sourceCode: string): boolean {
+    const head = leadingComments(sourceCode);
+    return GENERATED_HEADERS.some(header => header.test(head));
+}
+
+/**
+ * The comment and blank lines before the first line of code
+ * Build tags, license blocks and shebangs are comments, so a header below them is still found
+ */
+function leadingComments(sourceCode: string): string {
+    const lines: string[] = [];
+    let inBlock = false;
+
+    for (const line of sourceCode.slice(0, HEADER_SCAN_LENGTH).split('\n')) {
+        const trimmed = line.trim();
+        if (!inBlock && trimmed && !COMMENT_START.test(trimmed)) {
+            break;
+        }
+        lines.push(line);
+
+        if (inBlock) {
+            inBlock = !trimmed.includes('*/');
+        } else if (trimmed.startsWith('/*')) {
+            inBlock = !trimmed.slice(2).includes('*/');
+        }
+    }
+
+    return lines.join('\n');
+}
+
+/**
+ * Classify a file as generated or vendored code
+ *
+ * @param relativePath - Workspace-relative path
+ * @param sourceCode - File contents, to also check for a header
+ * @returns The kind, or undefined for hand-written project code
+ */
+export function classifyGenerated(relativePath: string, sourceCode?: string): GeneratedKind | undefined {
+    if (isVendoredPath(relativePath)) {
+        return 'vendored';
+    }
+    if (hasGeneratedName(relativePath) || (sourceCode !== undefined && hasGeneratedHeader(sourceCode))) {
+        return 'generated';
+    }
+    return undefined;
+}
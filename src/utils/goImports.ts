@@ -0,0 +1,46 @@
+/**
+ * Go import declarations, read lexically
+ * Shared by the call graph and the structure rules
+ */
+
+const GO_IMPORT_BLOCK = /^import\s*\(([\s\S]*?)^\)/gm;
+const GO_IMPORT_SINGLE = /^import\s+((?:[A-Za-z_.]\w*\s+)?"[^"]+")/gm;
+const GO_IMPORT_SPEC = /^\s*(?:([A-Za-z_.]\w*)\s+)?"([^"]+)"/gm;
+
+/**
+ * Imports of a Go file, by the name they are referenced with
+ * Blank (_) and dot imports are left out, since nothing selects through them
+ */
+export function goImports(sourceCode: string): Map<string, string> {
+    const specs: string[] = [];
+    for (const match of sourceCode.matchAll(GO_IMPORT_BLOCK)) {
+        specs.push(match[1]);
+    }
+    for (const match of sourceCode.matchAll(GO_IMPORT_SINGLE)) {
+        specs.push(match[1]);
+    }
+
+    const imports = new Map<string, string>();
+    for (const spec of specs) {
+        for (const match of spec.matchAll(GO_IMPORT_SPEC)) {
+            const [, alias, importPath] = match;
+            if (alias === '_' || alias === '.') {
+                continue;
+            }
+            // The package name is the last element, or the one before a major version (/v2)
+            const parts = importPath.split('/');
+            const last = parts.length > 1 && /^v\d+$/.test(parts[parts.length - 1])
+                ? parts[parts.length - 2]
+                : parts[parts.length - 1];
+            imports.set(alias || last, importPath);
+        }
+    }
+    return imports;
+}
+
+/**
+ * Standard library paths have no dot in their first element
+ */
+export function isStandardLibrary(importPath: string): boolean {
+    return !importPath.split('/')[0].includes('.');
+}
@@ -0,0 +1,31 @@
+/**
+ * Source file paths the workspace commands analyze
+ * Kept free of vscode so the detection modules can share them
+ */
+
+/**
+ * Files the complexity analyzer supports, as a findFiles glob
+ */
+export const SOURCE_GLOB = '**/*.{ts,tsx,js,jsx,mjs,cjs,go,py}';
+
+/**
+ * The same files, for paths that don't come from findFiles (git output)
+ */
+export const SOURCE_EXTENSION = /\.(ts|tsx|js|jsx|mjs|cjs|go|py)$/;
+
+/**
+ * Dependencies, build output and debug logs: never reviewed code
+ */
+export const EXCLUDED_DIRECTORIES = ['node_modules', '.git', 'dist', 'build', 'out', '.voight-debug'];
+
+/**
+ * EXCLUDED_DIRECTORIES as a findFiles exclude glob
+ */
+export const EXCLUDED_GLOB = `{${EXCLUDED_DIRECTORIES.map(dir => `**/${dir}/**`).join(',')}}`;
+
+/**
+ * Check whether a workspace-relative path is inside an excluded directory
+ */
+export function isInExcludedDirectory(relativePath: string): boolean {
+    return relativePath.split(/[\\/]/).slice(0, -1).some(segment => EXCLUDED_DIRECTORIES.includes(segment));
+}
@@ -0,0 +1,41 @@
+import * as vscode from 'vscode';
+import { Logger } from './logger';
+import { FilePatternMatcher } from './filePatternMatcher';
+import { EXCLUDED_GLOB, SOURCE_GLOB } from './sourcePaths';
+
+/**
+ * A workspace file and its contents
+ */
+export interface WorkspaceSource {
+    uri: vscode.Uri;
+    content: string;
+}
+
+/**
+ * Read every workspace file matching a glob, skipping excluded directories
+ * and generated or vendored code
+ * Open documents are read from their buffer, so results match what the user sees
+ *
+ * @param filter - Generated and vendored code filter
+ * @param label - Command name for log messages about unreadable files
+ * @param glob - Files to read, defaults to every supported language
+ */
+export async function readWorkspaceSources(
+    filter: FilePatternMatcher,
+    label: string,
+    glob: string = SOURCE_GLOB
+): Promise<WorkspaceSource[]> {
+    const sources: WorkspaceSource[] = [];
+    for (const uri of await vscode.workspace.findFiles(glob, EXCLUDED_GLOB)) {
+        try {
+            const open = vscode.workspace.textDocuments.find(doc => doc.uri.toString() === uri.toString());
+            const content = open ? open.getText() : Buffer.from(await vscode.workspace.fs.readFile(uri)).toString('utf8');
+            if (!filter.isExcludedGenerated(uri.fsPath, content)) {
+                sources.push({ uri, content });
+            }
+        } catch (error) {
+            Logger.warn(`${label} skipped ${uri.fsPath}: ${error}`);
+        }
+    }
+    return sources;
+}